@@ -0,0 +1,132 @@
+package airq
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// QueueInfo describes a queue of a namespace.
+type QueueInfo struct {
+	Name   string
+	Size   int64
+	Paused bool
+}
+
+// Filter selects jobs of a queue, a nil Filter selects every job.
+type Filter struct {
+	IDs    []string  // only these jobs
+	After  time.Time // jobs scheduled at or after
+	Before time.Time // jobs scheduled at or before
+	Limit  int       // maximum number of jobs, 0 means no limit
+}
+
+func (f *Filter) args() redis.Args {
+	if f == nil {
+		f = new(Filter)
+	}
+	min, max := int64(math.MinInt64), int64(math.MaxInt64)
+	if !f.After.IsZero() {
+		min = f.After.UnixNano()
+	}
+	if !f.Before.IsZero() {
+		max = f.Before.UnixNano()
+	}
+	return redis.Args{min, max, f.Limit}.AddFlat(f.IDs)
+}
+
+// ListQueues returns the queues registered in the namespace of q with their
+// size, sorted by name. A queue is registered by its first push.
+func (q *Queue) ListQueues() ([]QueueInfo, error) {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	res, err := redis.Values(listQueuesScript.Do(c, q.registry(), q.prefix()))
+	if err != nil {
+		return nil, err
+	}
+	queues := []QueueInfo{}
+	for len(res) > 0 {
+		var info QueueInfo
+		if res, err = redis.Scan(res, &info.Name, &info.Size, &info.Paused); err != nil {
+			return nil, err
+		}
+		queues = append(queues, info)
+	}
+	sort.Slice(queues, func(i, j int) bool { return queues[i].Name < queues[j].Name })
+	return queues, nil
+}
+
+// Purge removes every job of the queue and returns how many were removed. The
+// queue stays registered.
+func (q *Queue) Purge() (int64, error) {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	return redis.Int64(purgeScript.Do(c, append(redis.Args{q.key()}, q.trackArgs()...)...))
+}
+
+// Delete removes every key of the queue and unregisters it. The audit log of
+// an audited queue is kept, with the removal of its jobs.
+func (q *Queue) Delete() error {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	keysAndArgs := append(redis.Args{q.key(), q.registry()}, q.trackArgs()...)
+	_, err := deleteScript.Do(c, keysAndArgs.Add(q.Name)...)
+	return err
+}
+
+// MoveTo atomically moves the jobs of q selected by filter to other, keeping
+// their schedule. Both queues must live in the same redis database. The jobs
+// of a batch and the jobs other jobs depend on aren't moved. The moves are
+// recorded as removals in the audit log of q and as pushes in the one of
+// other.
+func (q *Queue) MoveTo(other *Queue, filter *Filter) (int64, error) {
+	if other.key() == q.key() {
+		return 0, fmt.Errorf("can't move queue %s to itself", q.Name)
+	}
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	keysAndArgs := append(redis.Args{q.key(), other.key(), other.registry()}, q.trackArgs()...)
+	keysAndArgs = append(keysAndArgs, other.auditArgs()...).Add(other.Name)
+	return redis.Int64(moveScript.Do(c, append(keysAndArgs, filter.args()...)...))
+}
+
+// Pause stops the queue from handing out jobs to any consumer until Resume is
+// called. Jobs can still be pushed to a paused queue.
+func (q *Queue) Pause() error {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	_, err := c.Do("SET", q.key()+":paused", 1)
+	return err
+}
+
+// Resume resumes a paused queue.
+func (q *Queue) Resume() error {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	_, err := c.Do("DEL", q.key()+":paused")
+	return err
+}
+
+// Paused tells whether the queue is paused.
+func (q *Queue) Paused() (bool, error) {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	return redis.Bool(c.Do("EXISTS", q.key()+":paused"))
+}
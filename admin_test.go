@@ -0,0 +1,167 @@
+package airq
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestListQueues(t *testing.T) {
+	q, teardown := setup(t, WithNamespace(randomName()))
+	defer teardown()
+	other := q.Sibling(randomName())
+	defer other.Delete()
+
+	addJobs(t, q, []Job{Job{Content: "a"}, Job{Content: "b"}})
+	addJobs(t, other, []Job{Job{Content: "c"}})
+	other.Pause()
+
+	queues, err := q.ListQueues()
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	expected := []QueueInfo{{Name: q.Name, Size: 2}, {Name: other.Name, Size: 1, Paused: true}}
+	if q.Name > other.Name {
+		expected[0], expected[1] = expected[1], expected[0]
+	}
+	if !reflect.DeepEqual(queues, expected) {
+		t.Error("Expected queues", expected, "but got", queues)
+	}
+
+	if err := other.Delete(); err != nil {
+		t.Error(err)
+	}
+	queues, _ = q.ListQueues()
+	if len(queues) != 1 || queues[0].Name != q.Name {
+		t.Error("Expected deleted queue to be unregistered, got", queues)
+	}
+}
+
+func TestPurge(t *testing.T) {
+	q, teardown := setup(t, WithNamespace(randomName()))
+	defer teardown()
+
+	addJobs(t, q, []Job{Job{Content: "a"}, Job{Content: "b"}})
+
+	n, err := q.Purge()
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if n != 2 {
+		t.Error("Expected 2 jobs purged, got", n)
+	}
+	if pending, _ := q.Pending(); pending != 0 {
+		t.Error("Expected no job pending after purge, got", pending)
+	}
+	queues, _ := q.ListQueues()
+	if len(queues) != 1 {
+		t.Error("Expected purged queue to stay registered, got", queues)
+	}
+}
+
+func TestMoveTo(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+	other := q.Sibling(randomName())
+	defer other.Delete()
+
+	now := time.Now()
+	addJobs(t, q, []Job{
+		Job{Content: "old", ID: "01", When: now.Add(-3 * time.Second)},
+		Job{Content: "recent", ID: "02", When: now.Add(-time.Second)},
+		Job{Content: "future", ID: "03", When: now.Add(time.Hour)},
+	})
+
+	n, err := q.MoveTo(other, &Filter{Before: now})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if n != 2 {
+		t.Error("Expected 2 jobs moved, got", n)
+	}
+	jobs, _ := other.PopJobs(10)
+	if expected := []string{"old", "recent"}; !reflect.DeepEqual(jobs, expected) {
+		t.Error("Expected moved jobs", expected, "but got", jobs)
+	}
+
+	if n, _ = q.MoveTo(other, &Filter{IDs: []string{"03", "unknown"}}); n != 1 {
+		t.Error("Expected 1 job moved by id, got", n)
+	}
+	if pending, _ := q.Pending(); pending != 0 {
+		t.Error("Expected source queue to be empty, got", pending)
+	}
+
+	if _, err := q.MoveTo(q.Sibling(q.Name), nil); err == nil {
+		t.Error("Expected an error moving a queue to itself")
+	}
+}
+
+func TestPause(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	if err := q.Pause(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if paused, _ := q.Paused(); !paused {
+		t.Error("Expected queue to be paused")
+	}
+
+	addJobs(t, q, []Job{Job{Content: "paused"}})
+	if pending, _ := q.Pending(); pending != 1 {
+		t.Error("Expected a paused queue to accept jobs, was", pending)
+	}
+	if job, _ := q.Pop(); job != "" {
+		t.Error("Expected a paused queue to return no job, got", job)
+	}
+
+	if err := q.Resume(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if job, _ := q.Pop(); job != "paused" {
+		t.Error("Expected to get the job after resume, got", job)
+	}
+}
+
+func TestMoveBatch(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+	other := q.Sibling(randomName())
+	defer other.Delete()
+
+	batch, _ := q.PushBatch([]*Job{&Job{ID: "a", Content: "a"}}, BatchOptions{})
+	if n, _ := q.MoveTo(other, nil); n != 0 {
+		t.Error("Expected the job of a batch to stay, got", n)
+	}
+	jobs, _ := q.Reserve(1)
+	if len(jobs) != 1 {
+		t.Error("Expected the job of the batch in the queue")
+		t.FailNow()
+	}
+	q.Ack(jobs[0].ID)
+	if status, _ := q.BatchStatus(batch); !status.Done() || status.Succeeded != 1 {
+		t.Error("Expected the batch to be done, got", status)
+	}
+}
+
+func TestMoveParent(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+	other := q.Sibling(randomName())
+	defer other.Delete()
+
+	ids, _ := q.Push(&Job{ID: "a", Content: "a"}, &Job{Content: "b", DependsOn: []string{"a"}})
+	if n, _ := q.MoveTo(other, nil); n != 0 {
+		t.Error("Expected the parent to stay, got", n)
+	}
+	q.Reserve(1)
+	q.Ack("a")
+	if jobs, _ := q.Reserve(1); len(jobs) != 1 || jobs[0].ID != ids[1] {
+		t.Error("Expected the child once its parent succeeded, got", jobs)
+	}
+}
@@ -0,0 +1,137 @@
+package airq
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Audit configures the audit log of a queue. Pushes, pops and removals are then
+// recorded in the `<queue>:events` stream by the scripts doing them.
+type Audit struct {
+	By        string        // who operates the queue, hostname:pid by default
+	MaxLen    int64         // approximate maximum of events kept, 0 for no limit
+	Retention time.Duration // how long events are kept, 0 for no limit
+}
+
+// WithAudit enables the audit log of the queue.
+func WithAudit(a Audit) Option {
+	if a.By == "" {
+		host, _ := os.Hostname()
+		a.By = fmt.Sprintf("%s:%d", host, os.Getpid())
+	}
+	return func(q *Queue) { q.audit = &a }
+}
+
+// auditArgs are the first arguments of the scripts recording their operations.
+func (q *Queue) auditArgs() redis.Args {
+	if q.audit == nil {
+		return redis.Args{"", 0, "0"}
+	}
+	minID := "0"
+	if q.audit.Retention > 0 {
+		minID = strconv.FormatInt(time.Now().Add(-q.audit.Retention).UnixNano()/int64(time.Millisecond), 10)
+	}
+	return redis.Args{q.audit.By, q.audit.MaxLen, minID}
+}
+
+// AuditEntry is an operation recorded in the audit log of a queue.
+type AuditEntry struct {
+	ID    string    // id of the entry in the stream
+	Op    EventKind // EventPush, EventPop or EventRemove
+	JobID string
+	By    string
+	Time  time.Time
+}
+
+// AuditIterator reads the audit log of a queue, see Queue.Events.
+type AuditIterator struct {
+	q     *Queue
+	ctx   context.Context
+	start string
+	buf   []AuditEntry
+	entry AuditEntry
+	err   error
+	done  bool
+}
+
+// auditPage is how many entries an AuditIterator reads at once.
+const auditPage = 100
+
+// Events returns an iterator over the audit log of the queue, from since to the
+// last recorded event.
+//
+//	it := q.Events(ctx, time.Now().Add(-time.Hour))
+//	for it.Next() {
+//		e := it.Entry()
+//	}
+//	if err := it.Err(); err != nil { ... }
+func (q *Queue) Events(ctx context.Context, since time.Time) *AuditIterator {
+	start := "-"
+	if !since.IsZero() {
+		start = strconv.FormatInt(since.UnixNano()/int64(time.Millisecond), 10)
+	}
+	return &AuditIterator{q: q, ctx: ctx, start: start}
+}
+
+// Next advances to the next entry, it returns false at the end of the log or
+// on error.
+func (it *AuditIterator) Next() bool {
+	if len(it.buf) == 0 && !it.done && it.err == nil {
+		it.fetch()
+	}
+	if len(it.buf) == 0 {
+		return false
+	}
+	it.entry, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Entry returns the current entry.
+func (it *AuditIterator) Entry() AuditEntry { return it.entry }
+
+// Err returns the error which stopped the iteration, if any.
+func (it *AuditIterator) Err() error { return it.err }
+
+func (it *AuditIterator) fetch() {
+	if it.err = it.ctx.Err(); it.err != nil {
+		return
+	}
+	c, managed := it.q.conn()
+	if managed {
+		defer c.Close()
+	}
+	res, err := redis.Values(c.Do("XRANGE", it.q.key()+":events", it.start, "+", "COUNT", auditPage))
+	if err != nil {
+		it.err = err
+		return
+	}
+	it.done = len(res) < auditPage
+	for _, r := range res {
+		var id string
+		var fields []string
+		if _, it.err = redis.Scan(r.([]interface{}), &id, &fields); it.err != nil {
+			return
+		}
+		entry := AuditEntry{ID: id}
+		for i := 0; i+1 < len(fields); i += 2 {
+			switch fields[i] {
+			case "op":
+				entry.Op = EventKind(fields[i+1])
+			case "id":
+				entry.JobID = fields[i+1]
+			case "by":
+				entry.By = fields[i+1]
+			}
+		}
+		ms, _ := strconv.ParseInt(strings.SplitN(id, "-", 2)[0], 10, 64)
+		entry.Time = time.Unix(0, ms*int64(time.Millisecond))
+		it.buf = append(it.buf, entry)
+		it.start = "(" + id
+	}
+}
@@ -0,0 +1,91 @@
+package airq
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAudit(t *testing.T) {
+	q, teardown := setup(t, WithAudit(Audit{By: "tester"}))
+	defer teardown()
+
+	start := time.Now().Add(-time.Second)
+	addJobs(t, q, []Job{Job{Content: "a", ID: "01"}, Job{Content: "b", ID: "02"}})
+	q.Pop()
+	q.Remove("02")
+
+	ops := []string{}
+	it := q.Events(context.Background(), start)
+	for it.Next() {
+		e := it.Entry()
+		if e.By != "tester" || e.Time.Before(start) {
+			t.Error("Unexpected audit entry", e)
+		}
+		ops = append(ops, fmt.Sprintf("%s %s", e.Op, e.JobID))
+	}
+	if err := it.Err(); err != nil {
+		t.Error(err)
+	}
+	expected := []string{"push 01", "push 02", "pop 01", "remove 02"}
+	if !reflect.DeepEqual(ops, expected) {
+		t.Error("Expected audit log", expected, "but got", ops)
+	}
+
+	it = q.Events(context.Background(), time.Now().Add(time.Minute))
+	if it.Next() {
+		t.Error("Expected no audit entry in the future, got", it.Entry())
+	}
+}
+
+func TestAuditRetention(t *testing.T) {
+	q, teardown := setup(t, WithAudit(Audit{MaxLen: 10}))
+	defer teardown()
+
+	for i := 0; i < 500; i++ {
+		addJobs(t, q, []Job{Job{Content: fmt.Sprint(i)}})
+	}
+	n := 0
+	for it := q.Events(context.Background(), time.Time{}); it.Next(); n++ {
+	}
+	if n >= 500 {
+		t.Error("Expected the audit log to be trimmed, got", n, "entries")
+	}
+
+	other := New(randomName(), WithConn(q.Conn))
+	defer other.Delete()
+	addJobs(t, other, []Job{Job{Content: "not audited"}})
+	if it := other.Events(context.Background(), time.Time{}); it.Next() {
+		t.Error("Expected no audit log without WithAudit, got", it.Entry())
+	}
+}
+
+func TestAuditAdmin(t *testing.T) {
+	q, teardown := setup(t, WithAudit(Audit{By: "tester"}))
+	defer teardown()
+	other := q.Sibling(randomName())
+	defer q.Conn.Do("DEL", other.key()+":events")
+
+	addJobs(t, q, []Job{Job{Content: "a", ID: "01"}, Job{Content: "b", ID: "02"}})
+	q.MoveTo(other, &Filter{IDs: []string{"01"}})
+	q.Purge()
+	other.Delete()
+
+	for _, tt := range []struct {
+		q        *Queue
+		expected []string
+	}{
+		{q, []string{"push 01", "push 02", "remove 01", "remove 02"}},
+		{other, []string{"push 01", "remove 01"}},
+	} {
+		ops := []string{}
+		for it := tt.q.Events(context.Background(), time.Time{}); it.Next(); {
+			ops = append(ops, fmt.Sprintf("%s %s", it.Entry().Op, it.Entry().JobID))
+		}
+		if !reflect.DeepEqual(ops, tt.expected) {
+			t.Error("Expected audit log", tt.expected, "but got", ops)
+		}
+	}
+}
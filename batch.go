@@ -0,0 +1,96 @@
+package airq
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// ErrInBatch is returned by PushBatch when a job is still pending in another
+// batch, none of the jobs is pushed then.
+var ErrInBatch = errors.New("job pending in another batch")
+
+// BatchOptions are the options of a batch of jobs pushed with PushBatch.
+type BatchOptions struct {
+	// OnComplete is pushed once every job of the batch succeeded or failed,
+	// with the ID of the batch in its "batch" metadata.
+	OnComplete *Job
+	// Retention is how long the status of a finished batch is kept, until the
+	// queue is purged or deleted when it is 0.
+	Retention time.Duration
+}
+
+// BatchStatus counts the jobs of a batch.
+type BatchStatus struct {
+	ID        string
+	Total     int64
+	Pending   int64 // jobs waiting, parked or in flight
+	Succeeded int64
+	Failed    int64 // jobs dead, canceled or removed
+}
+
+// Done tells whether every job of the batch succeeded or failed.
+func (s *BatchStatus) Done() bool { return s.Pending == 0 }
+
+// batch is a batch of jobs pushed at once.
+type batch struct {
+	id        string
+	callback  string
+	retention time.Duration
+}
+
+// args are the arguments of the push script for the batch.
+func (b *batch) args() redis.Args {
+	if b == nil {
+		return redis.Args{"", "", 0}
+	}
+	return redis.Args{b.id, b.callback, b.retention.Milliseconds()}
+}
+
+// PushBatch pushes jobs as a batch and returns its ID. The jobs completing are
+// counted in redis, see BatchStatus, and the OnComplete job is pushed once
+// they all completed. Jobs dropped by the MaxSize of the queue aren't part of
+// the batch, jobs pending in another batch fail it with ErrInBatch.
+func (q *Queue) PushBatch(jobs []*Job, opts BatchOptions) (string, error) {
+	if len(jobs) == 0 {
+		return "", fmt.Errorf("no jobs provided")
+	}
+	id := make([]byte, 16)
+	rand.Read(id)
+	b := &batch{id: hex.EncodeToString(id), retention: opts.Retention}
+	if cb := opts.OnComplete; cb != nil {
+		if cb.Metadata == nil {
+			cb.Metadata = make(map[string]string)
+		}
+		cb.Metadata["batch"] = b.id
+		b.callback = cb.String()
+	}
+	for _, j := range jobs {
+		j.Batch = b.id
+	}
+	_, err := q.pushContext(context.Background(), jobs, b)
+	return b.id, err
+}
+
+// BatchStatus returns the status of the batch id, or ErrNotFound.
+func (q *Queue) BatchStatus(id string) (*BatchStatus, error) {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	res, err := redis.Values(c.Do("HMGET", q.key()+":batch:"+id, "total", "pending", "succeeded", "failed"))
+	if err != nil {
+		return nil, err
+	}
+	if res[0] == nil {
+		return nil, ErrNotFound
+	}
+	s := &BatchStatus{ID: id}
+	_, err = redis.Scan(res, &s.Total, &s.Pending, &s.Succeeded, &s.Failed)
+	return s, err
+}
@@ -0,0 +1,64 @@
+package airq
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBatch(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	past := time.Now().Add(-time.Second)
+	id, err := q.PushBatch(
+		[]*Job{&Job{Content: "1", When: past}, &Job{Content: "2", When: past}, &Job{Content: "3", When: past}},
+		BatchOptions{OnComplete: &Job{Content: "done", When: past}, Retention: 50 * time.Millisecond},
+	)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	jobs, _ := q.Reserve(10)
+	if len(jobs) != 3 || jobs[0].Batch != id {
+		t.Error("Expected the 3 jobs of the batch, got", jobs)
+		t.FailNow()
+	}
+	q.Ack(jobs[0].ID, jobs[1].ID)
+	if s, err := q.BatchStatus(id); err != nil || s.Done() || s.Succeeded != 2 || s.Pending != 1 {
+		t.Error("Expected 1 pending job, got", s, err)
+	}
+	if jobs, _ := q.Reserve(10); len(jobs) != 0 {
+		t.Error("Expected no callback before the batch finished, got", jobs)
+	}
+	q.Bury(jobs[2].ID, errors.New("boom"))
+	if s, _ := q.BatchStatus(id); !s.Done() || s.Total != 3 || s.Failed != 1 {
+		t.Error("Expected the batch to be done with 1 failure, got", s)
+	}
+	jobs, _ = q.Reserve(10)
+	if len(jobs) != 1 || jobs[0].Content != "done" || jobs[0].Metadata["batch"] != id {
+		t.Error("Expected the callback of the batch, got", jobs)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := q.BatchStatus(id); err != ErrNotFound {
+		t.Error("Expected the status to expire, got", err)
+	}
+}
+
+func TestBatchPending(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	first, _ := q.PushBatch([]*Job{&Job{ID: "a", Content: "a"}}, BatchOptions{})
+	if _, err := q.PushBatch([]*Job{&Job{ID: "a", Content: "a"}}, BatchOptions{}); !errors.Is(err, ErrInBatch) {
+		t.Error("Expected ErrInBatch, got", err)
+	}
+	q.Reserve(1)
+	q.Ack("a")
+	if status, _ := q.BatchStatus(first); !status.Done() || status.Succeeded != 1 {
+		t.Error("Expected the first batch to finish, got", status)
+	}
+	if _, err := q.PushBatch([]*Job{&Job{ID: "a", Content: "a"}}, BatchOptions{}); err != nil {
+		t.Error("Expected the done job to be pushed in another batch, got", err)
+	}
+}
@@ -0,0 +1,48 @@
+package airq
+
+import "errors"
+
+// ErrQueueFull is returned by Push when the jobs would exceed the MaxSize of a
+// queue rejecting them, none of the jobs is pushed then.
+var ErrQueueFull = errors.New("queue full")
+
+// Overflow is what Push does with the jobs beyond the MaxSize of a queue.
+type Overflow int
+
+const (
+	OverflowReject     Overflow = iota // fail with ErrQueueFull
+	OverflowDropOldest                 // remove the waiting jobs due first
+	OverflowDropNewest                 // drop the pushed jobs which don't fit
+)
+
+// RedisArg implements redis.Argument with the name of the policy.
+func (o Overflow) RedisArg() interface{} {
+	switch o {
+	case OverflowDropOldest:
+		return "drop_oldest"
+	case OverflowDropNewest:
+		return "drop_newest"
+	}
+	return "reject"
+}
+
+// WithMaxSize bounds the jobs waiting in the queue to n, handling the jobs
+// beyond it with overflow.
+func WithMaxSize(n int, overflow Overflow) Option {
+	return func(q *Queue) { q.MaxSize, q.Overflow = n, overflow }
+}
+
+// keepJobs returns the jobs whose id isn't dropped.
+func keepJobs(jobs []*Job, dropped []string) []*Job {
+	drop := make(map[string]bool, len(dropped))
+	for _, id := range dropped {
+		drop[id] = true
+	}
+	kept := []*Job{}
+	for _, j := range jobs {
+		if !drop[j.ID] {
+			kept = append(kept, j)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,63 @@
+package airq
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMaxSize(t *testing.T) {
+	q, teardown := setup(t, WithMaxSize(2, OverflowReject))
+	defer teardown()
+
+	addJobs(t, q, []Job{Job{Content: "1"}, Job{Content: "2"}})
+	if _, err := q.Push(&Job{Content: "2"}); err != nil {
+		t.Error("Expected a job pushed again to be accepted, got", err)
+	}
+	if _, err := q.Push(&Job{Content: "3"}); !errors.Is(err, ErrQueueFull) {
+		t.Error("Expected ErrQueueFull, got", err)
+	}
+	if n, _ := q.Pending(); n != 2 {
+		t.Error("Expected 2 jobs, got", n)
+	}
+}
+
+func TestMaxSizeDrop(t *testing.T) {
+	q, teardown := setup(t, WithMaxSize(2, OverflowDropNewest))
+	defer teardown()
+
+	now := time.Now()
+	ids, err := q.Push(
+		&Job{Content: "1", When: now.Add(-2 * time.Second)},
+		&Job{Content: "2", When: now.Add(-time.Second)},
+		&Job{Content: "3", When: now},
+	)
+	if err != nil || len(ids) != 2 {
+		t.Error("Expected the third job to be dropped, got", ids, err)
+	}
+	q.Overflow = OverflowDropOldest
+	if _, err := q.Push(&Job{Content: "0", When: now.Add(-time.Hour)}, &Job{Content: "4", When: now.Add(time.Hour)}); err != nil {
+		t.Error(err)
+	}
+	if job, _ := q.Pop(); job != "2" {
+		t.Error("Expected the jobs due first to be dropped, got", job)
+	}
+	if n, _ := q.Pending(); n != 1 {
+		t.Error("Expected the job scheduled in an hour to be left, got", n)
+	}
+}
+
+func TestMaxSizeParked(t *testing.T) {
+	q, teardown := setup(t, WithMaxSize(2, OverflowReject))
+	defer teardown()
+
+	if _, err := q.Push(&Job{ID: "a", Content: "a"}, &Job{ID: "b", Content: "b", DependsOn: []string{"a"}}); err != nil {
+		t.Error(err)
+	}
+	if _, err := q.Push(&Job{Content: "c", DependsOn: []string{"a"}}); !errors.Is(err, ErrQueueFull) {
+		t.Error("Expected the parked jobs to count against MaxSize, got", err)
+	}
+	if _, err := q.Push(&Job{ID: "b", Content: "b", DependsOn: []string{"a"}}); err != nil {
+		t.Error("Expected a parked job pushed again to be accepted, got", err)
+	}
+}
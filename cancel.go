@@ -0,0 +1,71 @@
+package airq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// ErrCanceled is the cause of the cancellation of the context of a job
+// canceled with Cancel.
+var ErrCanceled = errors.New("job canceled")
+
+// Cancel cancels jobs of the queue from any process. Pending jobs are removed
+// while jobs in flight are flagged as canceled: the context of their handler
+// is canceled with the cause ErrCanceled by the worker running them, and they
+// are not delivered again. Running jobs are only watched by workers of a queue
+// with a Pool.
+func (q *Queue) Cancel(ids ...string) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("no id provided")
+	}
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	keysAndArgs := append(redis.Args{q.key()}, q.trackArgs()...).Add(q.ResultTTL.Milliseconds())
+	n, err := redis.Int(cancelScript.Do(c, keysAndArgs.AddFlat(ids)...))
+	if err == nil && n != len(ids) {
+		err = fmt.Errorf("can't cancel all jobs %v in queue %s", ids, q.Name)
+	}
+	return err
+}
+
+// jobContext returns the context of the handler of j, ended by the timeout of
+// j or by its cancellation, checked every interval.
+func (q *Queue) jobContext(ctx context.Context, j *Job, interval time.Duration) (context.Context, context.CancelFunc) {
+	stop := func() {}
+	if j.Timeout > 0 {
+		ctx, stop = context.WithTimeout(ctx, j.Timeout)
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	if q.Pool != nil {
+		go q.watchCancel(ctx, cancel, j.ID, interval)
+	}
+	return ctx, func() {
+		cancel(nil)
+		stop()
+	}
+}
+
+func (q *Queue) watchCancel(ctx context.Context, cancel context.CancelCauseFunc, id string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		c := q.Pool.Get()
+		canceled, _ := redis.Bool(c.Do("SISMEMBER", q.key()+":canceled", id))
+		c.Close()
+		if canceled {
+			cancel(ErrCanceled)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
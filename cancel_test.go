@@ -0,0 +1,98 @@
+package airq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+func TestCancelPending(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	ids, _ := q.Push(&Job{Content: "pending"})
+	if err := q.Cancel(ids...); err != nil {
+		t.Error(err)
+	}
+	if pending, _ := q.Pending(); pending != 0 {
+		t.Error("Expected the canceled job to be removed, got", pending)
+	}
+	if err := q.Cancel(ids...); err == nil {
+		t.Error("Expected an error canceling an unknown job")
+	}
+}
+
+func TestCancelRunning(t *testing.T) {
+	pool := &redis.Pool{Dial: func() (redis.Conn, error) { return redis.Dial("tcp", "127.0.0.1:6379") }}
+	defer pool.Close()
+	q, teardown := setup(t, WithPool(pool))
+	defer teardown()
+
+	ids, _ := q.Push(&Job{Content: "running"})
+	var cause error
+	h := HandlerFunc(func(ctx context.Context, j *Job) error {
+		if err := q.Cancel(j.ID); err != nil {
+			t.Error(err)
+		}
+		select {
+		case <-ctx.Done():
+			cause = context.Cause(ctx)
+		case <-time.After(time.Second):
+		}
+		return ctx.Err()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	q.Work(ctx, h, &LoopOptions{Sleep: 10 * time.Millisecond, CancelCheck: 10 * time.Millisecond})
+
+	if cause != ErrCanceled {
+		t.Error("Expected the job context to be canceled, got", cause)
+	}
+	if stats, _ := q.Stats(); stats.Due+stats.Scheduled+stats.InFlight+stats.Dead != 0 {
+		t.Error("Expected the canceled job", ids, "to be dropped, got", stats)
+	}
+}
+
+func TestJobTimeout(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	q.Push(&Job{Content: "slow", Timeout: 10 * time.Millisecond})
+	var err error
+	h := HandlerFunc(func(ctx context.Context, j *Job) error {
+		<-ctx.Done()
+		err = ctx.Err()
+		return err
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	q.Work(ctx, h, &LoopOptions{Sleep: 10 * time.Millisecond})
+
+	if err != context.DeadlineExceeded {
+		t.Error("Expected the job to time out, got", err)
+	}
+	if stats, _ := q.Stats(); stats.Scheduled != 1 {
+		t.Error("Expected the job to be retried, got", stats)
+	}
+}
+
+func TestCancelFailing(t *testing.T) {
+	q, teardown := setup(t, WithResultTTL(time.Minute))
+	defer teardown()
+
+	ids, _ := q.Push(&Job{Content: "failing"})
+	q.Reserve(1)
+	q.Cancel(ids...)
+	if err := q.Fail(ids[0], errors.New("boom")); err != nil {
+		t.Error(err)
+	}
+	if stats, _ := q.Stats(); stats.Due+stats.Scheduled+stats.InFlight+stats.Dead != 0 {
+		t.Error("Expected the canceled job not to be retried, got", stats)
+	}
+	if res, err := q.Wait(context.Background(), ids[0]); err != nil || res.Err != "job canceled" {
+		t.Error("Expected the result of the canceled job, got", res, err)
+	}
+}
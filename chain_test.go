@@ -0,0 +1,104 @@
+package airq
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestThen(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	past := time.Now().Add(-time.Second)
+	addJobs(t, q, []Job{Job{
+		Content: "first",
+		When:    past,
+		Then: &Job{
+			Content:  "next",
+			Metadata: map[string]string{"step": "2"},
+			Timeout:  time.Minute,
+			Then:     &Job{Content: "last"},
+		},
+		Catch: &Job{Content: "cleanup"},
+	}})
+	for _, expected := range []string{"first", "next", "last", ""} {
+		jobs, _ := q.Reserve(10)
+		if expected == "" {
+			if len(jobs) != 0 {
+				t.Error("Expected no follow-up, got", jobs)
+			}
+			break
+		}
+		if len(jobs) != 1 || jobs[0].Content != expected {
+			t.Error("Expected", expected, "but got", jobs)
+			t.FailNow()
+		}
+		if expected == "next" && (jobs[0].Metadata["step"] != "2" || jobs[0].Timeout != time.Minute) {
+			t.Error("Expected the follow-up to be kept whole, got", jobs[0])
+		}
+		if err := q.Ack(jobs[0].ID); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCatch(t *testing.T) {
+	q, teardown := setup(t, WithMaxAttempts(1))
+	defer teardown()
+
+	addJobs(t, q, []Job{Job{
+		Content: "first",
+		When:    time.Now().Add(-time.Second),
+		Then:    &Job{Content: "next"},
+		Catch:   &Job{Content: "cleanup"},
+	}})
+	jobs, _ := q.Reserve(10)
+	if len(jobs) != 1 || jobs[0].Catch == nil || jobs[0].Catch.Content != "cleanup" {
+		t.Error("Expected the job with its follow-ups, got", jobs)
+		t.FailNow()
+	}
+	q.Fail(jobs[0].ID, errors.New("boom"))
+	if jobs, _ := q.Reserve(10); len(jobs) != 1 || jobs[0].Content != "cleanup" {
+		t.Error("Expected the cleanup job, got", jobs)
+	}
+}
+
+func TestFollowUpChecks(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	for _, j := range []*Job{
+		&Job{Content: "a", Then: &Job{Content: "b", DependsOn: []string{"c"}}},
+		&Job{Content: "a", Catch: &Job{Content: "b", Then: &Job{Content: "c", Batch: "d"}}},
+	} {
+		if _, err := q.Push(j); err == nil {
+			t.Error("Expected an error on a follow-up with dependencies or a batch")
+		}
+	}
+	if stats, _ := q.Stats(); stats.Due != 0 {
+		t.Error("Expected no job pushed, got", stats.Due)
+	}
+}
+
+func TestPopSucceeds(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	past := time.Now().Add(-time.Second)
+	batch, _ := q.PushBatch([]*Job{&Job{ID: "a", Content: "a", When: past, Then: &Job{Content: "then", When: past}}}, BatchOptions{})
+	q.Push(&Job{Content: "child", DependsOn: []string{"a"}, When: past})
+	if job, _ := q.Pop(); job != "a" {
+		t.Error("Expected to pop a, got", job)
+	}
+	if status, _ := q.BatchStatus(batch); status.Succeeded != 1 {
+		t.Error("Expected the popped job to succeed in its batch, got", status)
+	}
+	jobs, _ := q.PopJobs(10)
+	sort.Strings(jobs)
+	if !reflect.DeepEqual(jobs, []string{"child", "then"}) {
+		t.Error("Expected the follow-up and the child of the popped job, got", jobs)
+	}
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 
 	"github.com/missena-corp/airq"
+	"github.com/missena-corp/airq/internal/grpctrace"
 	"github.com/missena-corp/airq/job"
 	"google.golang.org/grpc"
 )
 
+// UnaryInterceptor propagates the trace context of the calls to the server, it
+// has to be given to grpc.Dial with grpc.WithUnaryInterceptor.
+var UnaryInterceptor grpc.UnaryClientInterceptor = grpctrace.UnaryClientInterceptor
+
 type Client struct {
 	Conn *grpc.ClientConn
 }
@@ -22,17 +27,25 @@ func (c *Client) Push(ctx context.Context, jobs ...*airq.Job) (*job.IdList, erro
 	}
 	jobList := new(job.JobList)
 	for _, j := range jobs {
-		jobList.Jobs = append(jobList.Jobs, &job.Job{
-			Id:      j.ID,
-			Content: j.Content,
-			Unique:  j.Unique,
-			When:    j.When.UnixNano(),
-		})
+		jobList.Jobs = append(jobList.Jobs, toProto(j))
 	}
 	client := job.NewJobsClient(c.Conn)
 	return client.Push(ctx, jobList)
 }
 
+// Publish pushes the jobs to the queues subscribed to topic.
+func (c *Client) Publish(ctx context.Context, topic string, jobs ...*airq.Job) (*job.IdList, error) {
+	if len(jobs) == 0 {
+		return new(job.IdList), nil
+	}
+	pub := &job.Publication{Topic: topic}
+	for _, j := range jobs {
+		pub.Jobs = append(pub.Jobs, toProto(j))
+	}
+	client := job.NewJobsClient(c.Conn)
+	return client.Publish(ctx, pub)
+}
+
 func (c *Client) Remove(ctx context.Context, ids ...string) error {
 	if len(ids) == 0 {
 		return nil
@@ -45,3 +58,105 @@ func (c *Client) Remove(ctx context.Context, ids ...string) error {
 	_, err := client.Remove(ctx, idList)
 	return err
 }
+
+func (c *Client) Cancel(ctx context.Context, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	idList := new(job.IdList)
+	for _, i := range ids {
+		idList.Ids = append(idList.Ids, &job.Id{Id: i})
+	}
+	client := job.NewJobsClient(c.Conn)
+	_, err := client.Cancel(ctx, idList)
+	return err
+}
+
+func (c *Client) Get(ctx context.Context, id string) (*job.JobInfo, error) {
+	client := job.NewJobsClient(c.Conn)
+	return client.Get(ctx, &job.Id{Id: id})
+}
+
+// Await blocks until the job id completes and returns its result.
+func (c *Client) Await(ctx context.Context, id string) (*airq.Result, error) {
+	client := job.NewJobsClient(c.Conn)
+	res, err := client.Await(ctx, &job.Id{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return &airq.Result{Value: res.GetValue(), Err: res.GetError()}, nil
+}
+
+func (c *Client) ListQueues(ctx context.Context) (*job.QueueList, error) {
+	client := job.NewAdminClient(c.Conn)
+	return client.ListQueues(ctx, &job.Void{})
+}
+
+func (c *Client) Purge(ctx context.Context, name string) (int64, error) {
+	client := job.NewAdminClient(c.Conn)
+	count, err := client.Purge(ctx, &job.Queue{Name: name})
+	return count.GetCount(), err
+}
+
+func (c *Client) Delete(ctx context.Context, name string) error {
+	client := job.NewAdminClient(c.Conn)
+	_, err := client.Delete(ctx, &job.Queue{Name: name})
+	return err
+}
+
+func (c *Client) Move(ctx context.Context, from, to string, filter *airq.Filter) (int64, error) {
+	req := &job.MoveRequest{Source: from, Destination: to}
+	if filter != nil {
+		req.Ids = filter.IDs
+		req.Limit = int64(filter.Limit)
+		if !filter.After.IsZero() {
+			req.After = filter.After.UnixNano()
+		}
+		if !filter.Before.IsZero() {
+			req.Before = filter.Before.UnixNano()
+		}
+	}
+	client := job.NewAdminClient(c.Conn)
+	count, err := client.Move(ctx, req)
+	return count.GetCount(), err
+}
+
+func (c *Client) Pause(ctx context.Context, name string) error {
+	client := job.NewAdminClient(c.Conn)
+	_, err := client.Pause(ctx, &job.Queue{Name: name})
+	return err
+}
+
+func (c *Client) Resume(ctx context.Context, name string) error {
+	client := job.NewAdminClient(c.Conn)
+	_, err := client.Resume(ctx, &job.Queue{Name: name})
+	return err
+}
+
+func (c *Client) SetRateLimit(ctx context.Context, name string, rate float64, burst int) error {
+	client := job.NewAdminClient(c.Conn)
+	_, err := client.SetRateLimit(ctx, &job.RateLimit{Name: name, Rate: rate, Burst: int64(burst)})
+	return err
+}
+
+// toProto returns the message of a job with its follow-ups.
+func toProto(j *airq.Job) *job.Job {
+	if j == nil {
+		return nil
+	}
+	return &job.Job{
+		Id:        j.ID,
+		Catch:     toProto(j.Catch),
+		Content:   j.Content,
+		DependsOn: j.DependsOn,
+		GroupKey:  j.GroupKey,
+		Metadata:  j.Metadata,
+		Priority:  int32(j.Priority),
+		Tenant:    j.Tenant,
+		Then:      toProto(j.Then),
+		Timeout:   int64(j.Timeout),
+		Type:      j.Type,
+		Unique:    j.Unique,
+		When:      j.When.UnixNano(),
+	}
+}
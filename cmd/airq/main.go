@@ -0,0 +1,101 @@
+// Command airq administrates the queues served by an airq gRPC server.
+//
+//	airq [-addr host:port] list
+//	airq [-addr host:port] pause|resume|purge|delete <queue>
+//	airq [-addr host:port] ratelimit <queue> <jobs per second> [burst]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/missena-corp/airq/client"
+	"google.golang.org/grpc"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: airq [-addr host:port] list")
+	fmt.Fprintln(os.Stderr, "       airq [-addr host:port] pause|resume|purge|delete <queue>")
+	fmt.Fprintln(os.Stderr, "       airq [-addr host:port] ratelimit <queue> <jobs per second> [burst]")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:42039", "address of the airq gRPC server")
+	timeout := flag.Duration("timeout", 5*time.Second, "timeout of the command")
+	flag.Usage = usage
+	flag.Parse()
+	switch {
+	case flag.NArg() == 0:
+		usage()
+	case flag.Arg(0) == "ratelimit":
+		if flag.NArg() != 3 && flag.NArg() != 4 {
+			usage()
+		}
+	case flag.Arg(0) != "list" && flag.NArg() != 2:
+		usage()
+	}
+
+	conn, err := grpc.Dial(*addr, grpc.WithInsecure())
+	if err != nil {
+		fail(err)
+	}
+	defer conn.Close()
+	cli := client.New(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	name := flag.Arg(1)
+	switch flag.Arg(0) {
+	case "list":
+		queueList, err := cli.ListQueues(ctx)
+		if err != nil {
+			fail(err)
+		}
+		for _, q := range queueList.GetQueues() {
+			state := "active"
+			if q.GetPaused() {
+				state = "paused"
+			}
+			fmt.Printf("%s\t%d\t%s\n", q.GetName(), q.GetSize(), state)
+		}
+	case "pause":
+		err = cli.Pause(ctx, name)
+	case "resume":
+		err = cli.Resume(ctx, name)
+	case "purge":
+		var n int64
+		if n, err = cli.Purge(ctx, name); err == nil {
+			fmt.Printf("%d jobs purged from %s\n", n, name)
+		}
+	case "delete":
+		err = cli.Delete(ctx, name)
+	case "ratelimit":
+		var rate float64
+		var burst int
+		if rate, err = strconv.ParseFloat(flag.Arg(2), 64); err != nil {
+			usage()
+		}
+		if flag.NArg() == 4 {
+			if burst, err = strconv.Atoi(flag.Arg(3)); err != nil {
+				usage()
+			}
+		}
+		err = cli.SetRateLimit(ctx, name, rate, burst)
+	default:
+		usage()
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "airq:", err)
+	os.Exit(1)
+}
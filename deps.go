@@ -0,0 +1,31 @@
+package airq
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Workflow returns the state of the jobs ids and of the jobs parked until they
+// succeed, recursively. A job which left the queue has the state of its status
+// record when the queue tracks statuses, or the state of its result when the
+// queue keeps results, StateUnknown otherwise.
+func (q *Queue) Workflow(ids ...string) (map[string]State, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no id provided")
+	}
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	res, err := redis.StringMap(workflowScript.Do(c, redis.Args{q.key(), time.Now().UnixNano()}.AddFlat(ids)...))
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]State, len(res))
+	for id, state := range res {
+		states[id] = State(state)
+	}
+	return states, nil
+}
@@ -0,0 +1,126 @@
+package airq
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDependencies(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	past := time.Now().Add(-time.Second)
+	a, b := &Job{ID: "a", Content: "a", When: past}, &Job{ID: "b", Content: "b", When: past}
+	ids, err := q.Push(a, b, &Job{Content: "c", DependsOn: []string{a.ID, b.ID}, When: past})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	jobs, _ := q.Reserve(10)
+	if len(jobs) != 2 {
+		t.Error("Expected the parents only, got", jobs)
+	}
+	states, err := q.Workflow(a.ID)
+	if err != nil || states[a.ID] != StateInFlight || states[ids[2]] != StateParked {
+		t.Error("Expected a in flight and c parked, got", states, err)
+	}
+	q.Ack(a.ID)
+	if jobs, _ := q.Reserve(10); len(jobs) != 0 {
+		t.Error("Expected c to wait for b, got", jobs)
+	}
+	q.Ack(b.ID)
+	if jobs, _ := q.Reserve(10); len(jobs) != 1 || jobs[0].Content != "c" {
+		t.Error("Expected c once its parents succeeded, got", jobs)
+	}
+}
+
+func TestDependencyFailure(t *testing.T) {
+	q, teardown := setup(t, WithMaxAttempts(1))
+	defer teardown()
+
+	a := &Job{ID: "a", Content: "a", When: time.Now().Add(-time.Second)}
+	c := &Job{ID: "c", Content: "c", DependsOn: []string{a.ID}}
+	addJobs(t, q, []Job{*a})
+	if _, err := q.Push(c, &Job{Content: "d", DependsOn: []string{c.ID}}); err != nil {
+		t.Error(err)
+	}
+	jobs, _ := q.Reserve(10)
+	if len(jobs) != 1 {
+		t.Error("Expected the parent only, got", jobs)
+		t.FailNow()
+	}
+	if err := q.Fail(jobs[0].ID, errors.New("boom")); err != nil {
+		t.Error(err)
+	}
+	states, _ := q.Workflow(a.ID, c.ID)
+	for id, state := range states {
+		if state != StateDead {
+			t.Error("Expected job", id, "to be dead, got", state)
+		}
+	}
+	if len(states) != 2 {
+		t.Error("Expected the states of a and c, got", states)
+	}
+	if stats, _ := q.Stats(); stats.Dead != 3 {
+		t.Error("Expected the failure to propagate to 3 dead jobs, got", stats.Dead)
+	}
+	ids, _ := q.Push(&Job{Content: "late", DependsOn: []string{a.ID}})
+	if states, _ := q.Workflow(ids...); states[ids[0]] != StateDead {
+		t.Error("Expected a job depending on a dead job to be dead, got", states)
+	}
+}
+
+func TestCancelParked(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	a := &Job{ID: "a", Content: "a", When: time.Now().Add(-time.Second)}
+	ids, _ := q.Push(a, &Job{Content: "b", DependsOn: []string{a.ID}})
+	if err := q.Cancel(ids[1]); err != nil {
+		t.Error(err)
+	}
+	q.Reserve(1)
+	q.Ack(a.ID)
+	if jobs, _ := q.Reserve(1); len(jobs) != 0 {
+		t.Error("Expected the canceled job not to run, got", jobs)
+	}
+}
+
+func TestDependencyParents(t *testing.T) {
+	q, teardown := setup(t, WithResultTTL(time.Minute))
+	defer teardown()
+
+	a := &Job{ID: "a", Content: "a", When: time.Now().Add(-time.Second)}
+	ids, _ := q.Push(a,
+		&Job{Content: "twice", DependsOn: []string{a.ID, a.ID}},
+		&Job{ID: "self", Content: "self", DependsOn: []string{"self"}},
+		&Job{Content: "unknown", DependsOn: []string{"unknown"}},
+	)
+	states, _ := q.Workflow(ids[1:]...)
+	if states[ids[1]] != StateParked || states["self"] != StateDead || states[ids[3]] != StateDead {
+		t.Error("Expected the job depending on itself or an unknown job to be dead, got", states)
+	}
+	q.Reserve(1)
+	q.Ack(a.ID)
+	if jobs, _ := q.Reserve(1); len(jobs) != 1 || jobs[0].Content != "twice" {
+		t.Error("Expected the job depending twice on a to run, got", jobs)
+	}
+	ids, _ = q.Push(&Job{Content: "late", DependsOn: []string{a.ID}})
+	if jobs, _ := q.Reserve(1); len(jobs) != 1 || jobs[0].ID != ids[0] {
+		t.Error("Expected the job depending on a succeeded job to run, got", jobs)
+	}
+}
+
+func TestWorkflowUnknown(t *testing.T) {
+	q, teardown := setup(t, WithResultTTL(time.Minute))
+	defer teardown()
+
+	ids, _ := q.Push(&Job{Content: "a"})
+	q.Reserve(1)
+	q.Ack(ids[0])
+	states, err := q.Workflow(ids[0], "typo")
+	if err != nil || states[ids[0]] != StateSucceeded || states["typo"] != StateUnknown {
+		t.Error("Expected a succeeded and an unknown job, got", states, err)
+	}
+}
@@ -0,0 +1,47 @@
+package airq
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGroupKey(t *testing.T) {
+	q, teardown := setup(t, WithRetryDelay(time.Millisecond))
+	defer teardown()
+
+	now := time.Now()
+	addJobs(t, q, []Job{
+		Job{Content: "a1", GroupKey: "a", When: now.Add(-3 * time.Second)},
+		Job{Content: "a2", GroupKey: "a", When: now.Add(-2 * time.Second)},
+		Job{Content: "b1", GroupKey: "b", When: now.Add(-time.Second)},
+		Job{Content: "free"},
+	})
+
+	contents := func(jobs []*Job) (res []string) {
+		for _, j := range jobs {
+			res = append(res, j.Content)
+		}
+		return res
+	}
+	jobs, _ := q.Reserve(10)
+	if c := contents(jobs); len(c) != 3 || c[0] != "a1" || c[1] != "b1" || c[2] != "free" {
+		t.Error("Expected a job per group, got", c)
+		t.FailNow()
+	}
+	if more, _ := q.Reserve(10); len(more) != 0 {
+		t.Error("Expected the group a to be held by a1, got", contents(more))
+	}
+
+	q.Fail(jobs[0].ID, errors.New("boom"))
+	time.Sleep(5 * time.Millisecond)
+	if more, _ := q.Reserve(10); len(more) != 1 || more[0].Content != "a1" {
+		t.Error("Expected the group a to be held by a1 until its retry, got", contents(more))
+	}
+
+	q.Bury(jobs[0].ID, errors.New("fatal"))
+	q.Ack(jobs[1].ID)
+	if more, _ := q.Reserve(10); len(more) != 1 || more[0].Content != "a2" {
+		t.Error("Expected a2 once a1 is dead, got", contents(more))
+	}
+}
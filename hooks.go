@@ -0,0 +1,36 @@
+package airq
+
+// Hook is a callback run with an event of a queue. Hooks are run synchronously
+// by the operation which triggered them.
+type Hook func(*Event)
+
+type hook struct {
+	kind EventKind
+	f    Hook
+}
+
+func (h hook) Observe(e *Event) {
+	if e.Kind == h.kind {
+		h.f(e)
+	}
+}
+
+// OnPush registers a hook run after jobs are pushed.
+func OnPush(f Hook) Option { return WithObserver(hook{EventPush, f}) }
+
+// OnPop registers a hook run after jobs are popped or reserved, the When of
+// the jobs tells how long they waited.
+func OnPop(f Hook) Option { return WithObserver(hook{EventPop, f}) }
+
+// OnRemove registers a hook run after jobs are removed.
+func OnRemove(f Hook) Option { return WithObserver(hook{EventRemove, f}) }
+
+// OnAck registers a hook run after jobs are acknowledged.
+func OnAck(f Hook) Option { return WithObserver(hook{EventAck, f}) }
+
+// OnFail registers a hook run after a job failed, with the error of the job.
+func OnFail(f Hook) Option { return WithObserver(hook{EventFail, f}) }
+
+// OnDead registers a hook run after jobs are moved to the dead jobs, either
+// failed or with an expired lease on their last attempt.
+func OnDead(f Hook) Option { return WithObserver(hook{EventDead, f}) }
@@ -0,0 +1,50 @@
+package airq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	calls := map[EventKind][]string{}
+	record := func(e *Event) {
+		calls[e.Kind] = append(calls[e.Kind], e.IDs()...)
+		if e.Time.IsZero() {
+			t.Error("Expected the time of the event")
+		}
+	}
+	var failure error
+	q, teardown := setup(t,
+		WithMaxAttempts(1),
+		OnPush(record),
+		OnPop(record),
+		OnRemove(record),
+		OnAck(record),
+		OnFail(func(e *Event) { failure = e.Err }),
+		OnDead(record),
+	)
+	defer teardown()
+
+	addJobs(t, q, []Job{Job{Content: "a", ID: "01"}, Job{Content: "b", ID: "02"}})
+	q.Reserve(1)
+	q.Ack("01")
+	q.Reserve(1)
+	q.Fail("02", errors.New("boom"))
+	addJobs(t, q, []Job{Job{Content: "c", ID: "03"}})
+	q.Remove("03")
+
+	expected := map[EventKind][]string{
+		EventPush:   {"01", "02", "03"},
+		EventPop:    {"01", "02"},
+		EventAck:    {"01"},
+		EventDead:   {"02"},
+		EventRemove: {"03"},
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Error("Expected hooks to be called with", expected, "but got", calls)
+	}
+	if failure == nil || failure.Error() != "boom" {
+		t.Error("Expected the fail hook to get the error of the job, got", failure)
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"crypto/sha1"
 	"encoding/base64"
 	"encoding/hex"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"strings"
 	"time"
 
 	"github.com/vmihailenco/msgpack"
@@ -16,12 +18,26 @@ import (
 
 // Job is the struct of job in queue
 type Job struct {
-	CompressedContent string    `msgpack:"content"`
-	Content           string    `msgpack:"-"`
-	ID                string    `msgpack:"id"`
-	Unique            bool      `msgpack:"-"`
-	When              time.Time `msgpack:"-"`
-	WhenUnixNano      int64     `msgpack:"when"`
+	Attempts          int               `msgpack:"-"`
+	Batch             string            `msgpack:"batch,omitempty"`
+	Catch             *Job              `msgpack:"catch,omitempty"`
+	CompressedContent string            `msgpack:"content"`
+	Content           string            `msgpack:"-"`
+	DependsOn         []string          `msgpack:"depends_on,omitempty"`
+	GroupKey          string            `msgpack:"group,omitempty"`
+	ID                string            `msgpack:"id"`
+	Metadata          map[string]string `msgpack:"meta,omitempty"`
+	Priority          int               `msgpack:"priority,omitempty"`
+	Tenant            string            `msgpack:"tenant,omitempty"`
+	Then              *Job              `msgpack:"then,omitempty"`
+	Timeout           time.Duration     `msgpack:"timeout,omitempty"`
+	Type              string            `msgpack:"type,omitempty"`
+	Unique            bool              `msgpack:"-"`
+	When              time.Time         `msgpack:"-"`
+	WhenUnixNano      int64             `msgpack:"when"`
+
+	queue  *Queue // queue which reserved the job
+	result string
 }
 
 func compress(in string) string {
@@ -60,6 +76,28 @@ func (j *Job) setDefaults() {
 		j.When = time.Now()
 	}
 	j.WhenUnixNano = j.When.UnixNano()
+	for _, next := range []*Job{j.Then, j.Catch} {
+		if next != nil {
+			next.setDefaults()
+		}
+	}
+}
+
+// checkFollowUps fails when a follow-up of the job depends on other jobs or
+// belongs to a batch, which it would skip once pushed as the job completes.
+func (j *Job) checkFollowUps() error {
+	for _, next := range []*Job{j.Then, j.Catch} {
+		if next == nil {
+			continue
+		}
+		if len(next.DependsOn) > 0 || next.Batch != "" {
+			return fmt.Errorf("follow-up of job %s with dependencies or a batch", j.ID)
+		}
+		if err := next.checkFollowUps(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (j *Job) String() string {
@@ -67,3 +105,28 @@ func (j *Job) String() string {
 	b, _ := msgpack.Marshal(j)
 	return string(b)
 }
+
+// decode reads a job as stored in the values of a queue. Values stored before
+// jobs were kept whole only hold the compressed content.
+func decode(value string) *Job {
+	j := new(Job)
+	if strings.HasPrefix(value, "\x1f\x8b") {
+		j.CompressedContent = value
+	} else if err := msgpack.Unmarshal([]byte(value), j); err != nil {
+		return j
+	}
+	j.expand()
+	return j
+}
+
+// expand sets the fields of a decoded job and of its follow-ups which aren't
+// stored.
+func (j *Job) expand() {
+	j.Content = uncompress(j.CompressedContent)
+	j.When = time.Unix(0, j.WhenUnixNano)
+	for _, next := range []*Job{j.Then, j.Catch} {
+		if next != nil {
+			next.expand()
+		}
+	}
+}
@@ -100,13 +100,22 @@ func (m *IdList) GetIds() []*Id {
 }
 
 type Job struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Content              string   `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	Unique               bool     `protobuf:"varint,3,opt,name=unique,proto3" json:"unique,omitempty"`
-	When                 int64    `protobuf:"varint,4,opt,name=when,proto3" json:"when,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Content              string            `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Unique               bool              `protobuf:"varint,3,opt,name=unique,proto3" json:"unique,omitempty"`
+	When                 int64             `protobuf:"varint,4,opt,name=when,proto3" json:"when,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Type                 string            `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	Timeout              int64             `protobuf:"varint,7,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	GroupKey             string            `protobuf:"bytes,8,opt,name=group_key,json=groupKey,proto3" json:"group_key,omitempty"`
+	Priority             int32             `protobuf:"varint,9,opt,name=priority,proto3" json:"priority,omitempty"`
+	Tenant               string            `protobuf:"bytes,10,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	DependsOn            []string          `protobuf:"bytes,11,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	Then                 *Job              `protobuf:"bytes,12,opt,name=then,proto3" json:"then,omitempty"`
+	Catch                *Job              `protobuf:"bytes,13,opt,name=catch,proto3" json:"catch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *Job) Reset()         { *m = Job{} }
@@ -161,6 +170,185 @@ func (m *Job) GetWhen() int64 {
 	return 0
 }
 
+func (m *Job) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Job) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Job) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *Job) GetGroupKey() string {
+	if m != nil {
+		return m.GroupKey
+	}
+	return ""
+}
+
+func (m *Job) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Job) GetTenant() string {
+	if m != nil {
+		return m.Tenant
+	}
+	return ""
+}
+
+func (m *Job) GetDependsOn() []string {
+	if m != nil {
+		return m.DependsOn
+	}
+	return nil
+}
+
+func (m *Job) GetThen() *Job {
+	if m != nil {
+		return m.Then
+	}
+	return nil
+}
+
+func (m *Job) GetCatch() *Job {
+	if m != nil {
+		return m.Catch
+	}
+	return nil
+}
+
+type JobInfo struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	Attempts             int32    `protobuf:"varint,2,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	Progress             int32    `protobuf:"varint,3,opt,name=progress,proto3" json:"progress,omitempty"`
+	Message              string   `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Deadline             int64    `protobuf:"varint,5,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobInfo) Reset()         { *m = JobInfo{} }
+func (m *JobInfo) String() string { return proto.CompactTextString(m) }
+func (*JobInfo) ProtoMessage()    {}
+func (*JobInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{3}
+}
+func (m *JobInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobInfo.Unmarshal(m, b)
+}
+func (m *JobInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobInfo.Marshal(b, m, deterministic)
+}
+func (dst *JobInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobInfo.Merge(dst, src)
+}
+func (m *JobInfo) XXX_Size() int {
+	return xxx_messageInfo_JobInfo.Size(m)
+}
+func (m *JobInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobInfo proto.InternalMessageInfo
+
+func (m *JobInfo) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *JobInfo) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *JobInfo) GetProgress() int32 {
+	if m != nil {
+		return m.Progress
+	}
+	return 0
+}
+
+func (m *JobInfo) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *JobInfo) GetDeadline() int64 {
+	if m != nil {
+		return m.Deadline
+	}
+	return 0
+}
+
+type Result struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Result) Reset()         { *m = Result{} }
+func (m *Result) String() string { return proto.CompactTextString(m) }
+func (*Result) ProtoMessage()    {}
+func (*Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{4}
+}
+func (m *Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Result.Unmarshal(m, b)
+}
+func (m *Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Result.Marshal(b, m, deterministic)
+}
+func (dst *Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Result.Merge(dst, src)
+}
+func (m *Result) XXX_Size() int {
+	return xxx_messageInfo_Result.Size(m)
+}
+func (m *Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Result proto.InternalMessageInfo
+
+func (m *Result) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *Result) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 type JobList struct {
 	Jobs                 []*Job   `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -172,7 +360,7 @@ func (m *JobList) Reset()         { *m = JobList{} }
 func (m *JobList) String() string { return proto.CompactTextString(m) }
 func (*JobList) ProtoMessage()    {}
 func (*JobList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f32c477d91a04ead, []int{3}
+	return fileDescriptor_f32c477d91a04ead, []int{5}
 }
 func (m *JobList) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JobList.Unmarshal(m, b)
@@ -199,6 +387,52 @@ func (m *JobList) GetJobs() []*Job {
 	return nil
 }
 
+type Publication struct {
+	Topic                string   `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Jobs                 []*Job   `protobuf:"bytes,2,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Publication) Reset()         { *m = Publication{} }
+func (m *Publication) String() string { return proto.CompactTextString(m) }
+func (*Publication) ProtoMessage()    {}
+func (*Publication) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{6}
+}
+func (m *Publication) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Publication.Unmarshal(m, b)
+}
+func (m *Publication) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Publication.Marshal(b, m, deterministic)
+}
+func (dst *Publication) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Publication.Merge(dst, src)
+}
+func (m *Publication) XXX_Size() int {
+	return xxx_messageInfo_Publication.Size(m)
+}
+func (m *Publication) XXX_DiscardUnknown() {
+	xxx_messageInfo_Publication.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Publication proto.InternalMessageInfo
+
+func (m *Publication) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *Publication) GetJobs() []*Job {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
 type Void struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -209,7 +443,7 @@ func (m *Void) Reset()         { *m = Void{} }
 func (m *Void) String() string { return proto.CompactTextString(m) }
 func (*Void) ProtoMessage()    {}
 func (*Void) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f32c477d91a04ead, []int{4}
+	return fileDescriptor_f32c477d91a04ead, []int{7}
 }
 func (m *Void) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Void.Unmarshal(m, b)
@@ -229,94 +463,409 @@ func (m *Void) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Void proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterType((*Id)(nil), "Id")
-	proto.RegisterType((*IdList)(nil), "IdList")
-	proto.RegisterType((*Job)(nil), "Job")
-	proto.RegisterType((*JobList)(nil), "JobList")
-	proto.RegisterType((*Void)(nil), "Void")
+type Queue struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size                 int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Paused               bool     `protobuf:"varint,3,opt,name=paused,proto3" json:"paused,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *Queue) Reset()         { *m = Queue{} }
+func (m *Queue) String() string { return proto.CompactTextString(m) }
+func (*Queue) ProtoMessage()    {}
+func (*Queue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{8}
+}
+func (m *Queue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Queue.Unmarshal(m, b)
+}
+func (m *Queue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Queue.Marshal(b, m, deterministic)
+}
+func (dst *Queue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Queue.Merge(dst, src)
+}
+func (m *Queue) XXX_Size() int {
+	return xxx_messageInfo_Queue.Size(m)
+}
+func (m *Queue) XXX_DiscardUnknown() {
+	xxx_messageInfo_Queue.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_Queue proto.InternalMessageInfo
 
-// JobsClient is the client API for Jobs service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type JobsClient interface {
-	Push(ctx context.Context, in *JobList, opts ...grpc.CallOption) (*IdList, error)
-	Remove(ctx context.Context, in *IdList, opts ...grpc.CallOption) (*Void, error)
+func (m *Queue) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-type jobsClient struct {
-	cc *grpc.ClientConn
+func (m *Queue) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
 }
 
-func NewJobsClient(cc *grpc.ClientConn) JobsClient {
-	return &jobsClient{cc}
+func (m *Queue) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
 }
 
-func (c *jobsClient) Push(ctx context.Context, in *JobList, opts ...grpc.CallOption) (*IdList, error) {
-	out := new(IdList)
-	err := c.cc.Invoke(ctx, "/Jobs/Push", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type QueueList struct {
+	Queues               []*Queue `protobuf:"bytes,1,rep,name=queues,proto3" json:"queues,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *jobsClient) Remove(ctx context.Context, in *IdList, opts ...grpc.CallOption) (*Void, error) {
-	out := new(Void)
-	err := c.cc.Invoke(ctx, "/Jobs/Remove", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *QueueList) Reset()         { *m = QueueList{} }
+func (m *QueueList) String() string { return proto.CompactTextString(m) }
+func (*QueueList) ProtoMessage()    {}
+func (*QueueList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{9}
+}
+func (m *QueueList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueueList.Unmarshal(m, b)
+}
+func (m *QueueList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueueList.Marshal(b, m, deterministic)
+}
+func (dst *QueueList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueueList.Merge(dst, src)
+}
+func (m *QueueList) XXX_Size() int {
+	return xxx_messageInfo_QueueList.Size(m)
+}
+func (m *QueueList) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueueList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueueList proto.InternalMessageInfo
+
+func (m *QueueList) GetQueues() []*Queue {
+	if m != nil {
+		return m.Queues
 	}
-	return out, nil
+	return nil
 }
 
-// JobsServer is the server API for Jobs service.
-type JobsServer interface {
-	Push(context.Context, *JobList) (*IdList, error)
-	Remove(context.Context, *IdList) (*Void, error)
+type MoveRequest struct {
+	Source               string   `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Destination          string   `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Ids                  []string `protobuf:"bytes,3,rep,name=ids,proto3" json:"ids,omitempty"`
+	After                int64    `protobuf:"varint,4,opt,name=after,proto3" json:"after,omitempty"`
+	Before               int64    `protobuf:"varint,5,opt,name=before,proto3" json:"before,omitempty"`
+	Limit                int64    `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func RegisterJobsServer(s *grpc.Server, srv JobsServer) {
-	s.RegisterService(&_Jobs_serviceDesc, srv)
+func (m *MoveRequest) Reset()         { *m = MoveRequest{} }
+func (m *MoveRequest) String() string { return proto.CompactTextString(m) }
+func (*MoveRequest) ProtoMessage()    {}
+func (*MoveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{10}
+}
+func (m *MoveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveRequest.Unmarshal(m, b)
+}
+func (m *MoveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveRequest.Marshal(b, m, deterministic)
+}
+func (dst *MoveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveRequest.Merge(dst, src)
+}
+func (m *MoveRequest) XXX_Size() int {
+	return xxx_messageInfo_MoveRequest.Size(m)
+}
+func (m *MoveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveRequest.DiscardUnknown(m)
 }
 
-func _Jobs_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(JobList)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(JobsServer).Push(ctx, in)
+var xxx_messageInfo_MoveRequest proto.InternalMessageInfo
+
+func (m *MoveRequest) GetSource() string {
+	if m != nil {
+		return m.Source
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/Jobs/Push",
+	return ""
+}
+
+func (m *MoveRequest) GetDestination() string {
+	if m != nil {
+		return m.Destination
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobsServer).Push(ctx, req.(*JobList))
+	return ""
+}
+
+func (m *MoveRequest) GetIds() []string {
+	if m != nil {
+		return m.Ids
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Jobs_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IdList)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *MoveRequest) GetAfter() int64 {
+	if m != nil {
+		return m.After
 	}
-	if interceptor == nil {
-		return srv.(JobsServer).Remove(ctx, in)
+	return 0
+}
+
+func (m *MoveRequest) GetBefore() int64 {
+	if m != nil {
+		return m.Before
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
+	return 0
+}
+
+func (m *MoveRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type RateLimit struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rate                 float64  `protobuf:"fixed64,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	Burst                int64    `protobuf:"varint,3,opt,name=burst,proto3" json:"burst,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RateLimit) Reset()         { *m = RateLimit{} }
+func (m *RateLimit) String() string { return proto.CompactTextString(m) }
+func (*RateLimit) ProtoMessage()    {}
+func (*RateLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{11}
+}
+func (m *RateLimit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RateLimit.Unmarshal(m, b)
+}
+func (m *RateLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RateLimit.Marshal(b, m, deterministic)
+}
+func (dst *RateLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RateLimit.Merge(dst, src)
+}
+func (m *RateLimit) XXX_Size() int {
+	return xxx_messageInfo_RateLimit.Size(m)
+}
+func (m *RateLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_RateLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RateLimit proto.InternalMessageInfo
+
+func (m *RateLimit) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RateLimit) GetRate() float64 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+func (m *RateLimit) GetBurst() int64 {
+	if m != nil {
+		return m.Burst
+	}
+	return 0
+}
+
+type Count struct {
+	Count                int64    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Count) Reset()         { *m = Count{} }
+func (m *Count) String() string { return proto.CompactTextString(m) }
+func (*Count) ProtoMessage()    {}
+func (*Count) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{12}
+}
+func (m *Count) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Count.Unmarshal(m, b)
+}
+func (m *Count) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Count.Marshal(b, m, deterministic)
+}
+func (dst *Count) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Count.Merge(dst, src)
+}
+func (m *Count) XXX_Size() int {
+	return xxx_messageInfo_Count.Size(m)
+}
+func (m *Count) XXX_DiscardUnknown() {
+	xxx_messageInfo_Count.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Count proto.InternalMessageInfo
+
+func (m *Count) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Id)(nil), "Id")
+	proto.RegisterType((*IdList)(nil), "IdList")
+	proto.RegisterType((*Job)(nil), "Job")
+	proto.RegisterMapType((map[string]string)(nil), "Job.MetadataEntry")
+	proto.RegisterType((*JobInfo)(nil), "JobInfo")
+	proto.RegisterType((*Result)(nil), "Result")
+	proto.RegisterType((*JobList)(nil), "JobList")
+	proto.RegisterType((*Publication)(nil), "Publication")
+	proto.RegisterType((*Void)(nil), "Void")
+	proto.RegisterType((*Queue)(nil), "Queue")
+	proto.RegisterType((*QueueList)(nil), "QueueList")
+	proto.RegisterType((*MoveRequest)(nil), "MoveRequest")
+	proto.RegisterType((*RateLimit)(nil), "RateLimit")
+	proto.RegisterType((*Count)(nil), "Count")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// JobsClient is the client API for Jobs service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type JobsClient interface {
+	Push(ctx context.Context, in *JobList, opts ...grpc.CallOption) (*IdList, error)
+	Remove(ctx context.Context, in *IdList, opts ...grpc.CallOption) (*Void, error)
+	Cancel(ctx context.Context, in *IdList, opts ...grpc.CallOption) (*Void, error)
+	Get(ctx context.Context, in *Id, opts ...grpc.CallOption) (*JobInfo, error)
+	Await(ctx context.Context, in *Id, opts ...grpc.CallOption) (*Result, error)
+	Publish(ctx context.Context, in *Publication, opts ...grpc.CallOption) (*IdList, error)
+}
+
+type jobsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewJobsClient(cc *grpc.ClientConn) JobsClient {
+	return &jobsClient{cc}
+}
+
+func (c *jobsClient) Push(ctx context.Context, in *JobList, opts ...grpc.CallOption) (*IdList, error) {
+	out := new(IdList)
+	err := c.cc.Invoke(ctx, "/Jobs/Push", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobsClient) Remove(ctx context.Context, in *IdList, opts ...grpc.CallOption) (*Void, error) {
+	out := new(Void)
+	err := c.cc.Invoke(ctx, "/Jobs/Remove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobsClient) Cancel(ctx context.Context, in *IdList, opts ...grpc.CallOption) (*Void, error) {
+	out := new(Void)
+	err := c.cc.Invoke(ctx, "/Jobs/Cancel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobsClient) Get(ctx context.Context, in *Id, opts ...grpc.CallOption) (*JobInfo, error) {
+	out := new(JobInfo)
+	err := c.cc.Invoke(ctx, "/Jobs/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobsClient) Await(ctx context.Context, in *Id, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, "/Jobs/Await", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobsClient) Publish(ctx context.Context, in *Publication, opts ...grpc.CallOption) (*IdList, error) {
+	out := new(IdList)
+	err := c.cc.Invoke(ctx, "/Jobs/Publish", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JobsServer is the server API for Jobs service.
+type JobsServer interface {
+	Push(context.Context, *JobList) (*IdList, error)
+	Remove(context.Context, *IdList) (*Void, error)
+	Cancel(context.Context, *IdList) (*Void, error)
+	Get(context.Context, *Id) (*JobInfo, error)
+	Await(context.Context, *Id) (*Result, error)
+	Publish(context.Context, *Publication) (*IdList, error)
+}
+
+func RegisterJobsServer(s *grpc.Server, srv JobsServer) {
+	s.RegisterService(&_Jobs_serviceDesc, srv)
+}
+
+func _Jobs_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServer).Push(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Jobs/Push",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobsServer).Push(ctx, req.(*JobList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Jobs_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServer).Remove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
 		FullMethod: "/Jobs/Remove",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
@@ -325,6 +874,78 @@ func _Jobs_Remove_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Jobs_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Jobs/Cancel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobsServer).Cancel(ctx, req.(*IdList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Jobs_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Id)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Jobs/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobsServer).Get(ctx, req.(*Id))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Jobs_Await_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Id)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServer).Await(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Jobs/Await",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobsServer).Await(ctx, req.(*Id))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Jobs_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Publication)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Jobs/Publish",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobsServer).Publish(ctx, req.(*Publication))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Jobs_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "Jobs",
 	HandlerType: (*JobsServer)(nil),
@@ -337,6 +958,284 @@ var _Jobs_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Remove",
 			Handler:    _Jobs_Remove_Handler,
 		},
+		{
+			MethodName: "Cancel",
+			Handler:    _Jobs_Cancel_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _Jobs_Get_Handler,
+		},
+		{
+			MethodName: "Await",
+			Handler:    _Jobs_Await_Handler,
+		},
+		{
+			MethodName: "Publish",
+			Handler:    _Jobs_Publish_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "job.proto",
+}
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminClient interface {
+	ListQueues(ctx context.Context, in *Void, opts ...grpc.CallOption) (*QueueList, error)
+	Purge(ctx context.Context, in *Queue, opts ...grpc.CallOption) (*Count, error)
+	Delete(ctx context.Context, in *Queue, opts ...grpc.CallOption) (*Void, error)
+	Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (*Count, error)
+	Pause(ctx context.Context, in *Queue, opts ...grpc.CallOption) (*Void, error)
+	Resume(ctx context.Context, in *Queue, opts ...grpc.CallOption) (*Void, error)
+	SetRateLimit(ctx context.Context, in *RateLimit, opts ...grpc.CallOption) (*Void, error)
+}
+
+type adminClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminClient(cc *grpc.ClientConn) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) ListQueues(ctx context.Context, in *Void, opts ...grpc.CallOption) (*QueueList, error) {
+	out := new(QueueList)
+	err := c.cc.Invoke(ctx, "/Admin/ListQueues", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) Purge(ctx context.Context, in *Queue, opts ...grpc.CallOption) (*Count, error) {
+	out := new(Count)
+	err := c.cc.Invoke(ctx, "/Admin/Purge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) Delete(ctx context.Context, in *Queue, opts ...grpc.CallOption) (*Void, error) {
+	out := new(Void)
+	err := c.cc.Invoke(ctx, "/Admin/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (*Count, error) {
+	out := new(Count)
+	err := c.cc.Invoke(ctx, "/Admin/Move", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) Pause(ctx context.Context, in *Queue, opts ...grpc.CallOption) (*Void, error) {
+	out := new(Void)
+	err := c.cc.Invoke(ctx, "/Admin/Pause", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) Resume(ctx context.Context, in *Queue, opts ...grpc.CallOption) (*Void, error) {
+	out := new(Void)
+	err := c.cc.Invoke(ctx, "/Admin/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetRateLimit(ctx context.Context, in *RateLimit, opts ...grpc.CallOption) (*Void, error) {
+	out := new(Void)
+	err := c.cc.Invoke(ctx, "/Admin/SetRateLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+type AdminServer interface {
+	ListQueues(context.Context, *Void) (*QueueList, error)
+	Purge(context.Context, *Queue) (*Count, error)
+	Delete(context.Context, *Queue) (*Void, error)
+	Move(context.Context, *MoveRequest) (*Count, error)
+	Pause(context.Context, *Queue) (*Void, error)
+	Resume(context.Context, *Queue) (*Void, error)
+	SetRateLimit(context.Context, *RateLimit) (*Void, error)
+}
+
+func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
+	s.RegisterService(&_Admin_serviceDesc, srv)
+}
+
+func _Admin_ListQueues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Void)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListQueues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Admin/ListQueues",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListQueues(ctx, req.(*Void))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_Purge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Queue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Purge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Admin/Purge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Purge(ctx, req.(*Queue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Queue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Admin/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Delete(ctx, req.(*Queue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_Move_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Move(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Admin/Move",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Move(ctx, req.(*MoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Queue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Admin/Pause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Pause(ctx, req.(*Queue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Queue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Admin/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Resume(ctx, req.(*Queue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetRateLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateLimit)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetRateLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Admin/SetRateLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetRateLimit(ctx, req.(*RateLimit))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Admin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListQueues",
+			Handler:    _Admin_ListQueues_Handler,
+		},
+		{
+			MethodName: "Purge",
+			Handler:    _Admin_Purge_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Admin_Delete_Handler,
+		},
+		{
+			MethodName: "Move",
+			Handler:    _Admin_Move_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _Admin_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _Admin_Resume_Handler,
+		},
+		{
+			MethodName: "SetRateLimit",
+			Handler:    _Admin_SetRateLimit_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "job.proto",
@@ -345,19 +1244,56 @@ var _Jobs_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("job.proto", fileDescriptor_f32c477d91a04ead) }
 
 var fileDescriptor_f32c477d91a04ead = []byte{
-	// 217 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x90, 0xb1, 0x4b, 0xc4, 0x30,
-	0x14, 0x87, 0x49, 0x13, 0xd3, 0xbb, 0x27, 0x38, 0x3c, 0x54, 0x9e, 0xb7, 0x58, 0xe2, 0xd2, 0x29,
-	0xc3, 0x39, 0xfa, 0x17, 0xb4, 0x38, 0x48, 0x06, 0x17, 0xb7, 0x98, 0xc0, 0xe5, 0xc0, 0x3e, 0x35,
-	0xa9, 0xfe, 0xfb, 0xd2, 0xd0, 0x2e, 0x6e, 0xf9, 0x3e, 0xf8, 0xf8, 0x91, 0x07, 0xfb, 0x33, 0x7b,
-	0xfb, 0xf9, 0xcd, 0x85, 0xcd, 0x35, 0x34, 0x43, 0xc0, 0x2b, 0x68, 0x52, 0x20, 0xd1, 0x89, 0x7e,
-	0xef, 0x9a, 0x14, 0xcc, 0x3d, 0xe8, 0x21, 0x3c, 0xa7, 0x5c, 0xf0, 0x06, 0x64, 0x0a, 0x99, 0x44,
-	0x27, 0xfb, 0xcb, 0xa3, 0xb4, 0x43, 0x70, 0x0b, 0x9b, 0x37, 0x90, 0x23, 0xfb, 0xff, 0x1d, 0x12,
-	0xb4, 0xef, 0x3c, 0x95, 0x38, 0x15, 0x6a, 0xaa, 0xdc, 0x10, 0x6f, 0x41, 0xcf, 0x53, 0xfa, 0x9a,
-	0x23, 0xc9, 0x4e, 0xf4, 0x3b, 0xb7, 0x12, 0x22, 0xa8, 0xdf, 0x53, 0x9c, 0x48, 0x75, 0xa2, 0x97,
-	0xae, 0xbe, 0xcd, 0x03, 0xb4, 0x23, 0xfb, 0x3a, 0x4f, 0xa0, 0xce, 0xec, 0xb7, 0x7d, 0x65, 0x47,
-	0xf6, 0xae, 0x1a, 0xa3, 0x41, 0xbd, 0x72, 0x0a, 0xc7, 0x27, 0x50, 0x23, 0xfb, 0x8c, 0x77, 0xa0,
-	0x5e, 0xe6, 0x7c, 0xc2, 0x9d, 0x5d, 0xdb, 0x43, 0x6b, 0xd7, 0x3f, 0x10, 0x68, 0x17, 0x3f, 0xf8,
-	0x27, 0xe2, 0xa6, 0x0e, 0x17, 0x76, 0x89, 0xbd, 0xae, 0x47, 0x78, 0xfc, 0x0b, 0x00, 0x00, 0xff,
-	0xff, 0x71, 0x49, 0x79, 0x00, 0x11, 0x01, 0x00, 0x00,
+	// 808 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0xdd, 0x6e, 0xe3, 0x44,
+	0x14, 0x96, 0x33, 0xb6, 0x13, 0x9f, 0x74, 0xd1, 0x6a, 0xd4, 0x6d, 0x87, 0xc0, 0xb2, 0xc6, 0xdc,
+	0x44, 0x42, 0xf2, 0x45, 0xe1, 0x02, 0x81, 0xb8, 0x58, 0x2d, 0x68, 0x95, 0xb2, 0x2b, 0xca, 0x20,
+	0x71, 0xbb, 0x1a, 0xc7, 0xa7, 0xed, 0x94, 0x78, 0x26, 0xf5, 0x8c, 0xbb, 0x0a, 0x2f, 0xc1, 0x0b,
+	0xc0, 0x1d, 0x8f, 0xc3, 0x43, 0xa1, 0xf9, 0x71, 0x1a, 0x5a, 0xed, 0xdd, 0xf9, 0xbe, 0xf1, 0x39,
+	0x73, 0xce, 0x77, 0xbe, 0x49, 0xa0, 0xb8, 0xd1, 0x4d, 0xbd, 0xed, 0xb5, 0xd5, 0xd5, 0x31, 0x4c,
+	0x56, 0x2d, 0xfd, 0x08, 0x26, 0xb2, 0x65, 0x49, 0x99, 0x2c, 0x0b, 0x3e, 0x91, 0x6d, 0xf5, 0x02,
+	0xf2, 0x55, 0xfb, 0x46, 0x1a, 0x4b, 0x9f, 0x01, 0x91, 0xad, 0x61, 0x49, 0x49, 0x96, 0xf3, 0x33,
+	0x52, 0xaf, 0x5a, 0xee, 0x70, 0xf5, 0x17, 0x01, 0x72, 0xae, 0x9b, 0x87, 0x89, 0x94, 0xc1, 0x74,
+	0xad, 0x95, 0x45, 0x65, 0xd9, 0xc4, 0x93, 0x23, 0xa4, 0x27, 0x90, 0x0f, 0x4a, 0xde, 0x0e, 0xc8,
+	0x48, 0x99, 0x2c, 0x67, 0x3c, 0x22, 0x4a, 0x21, 0x7d, 0x7f, 0x8d, 0x8a, 0xa5, 0x65, 0xb2, 0x24,
+	0xdc, 0xc7, 0xb4, 0x86, 0x59, 0x87, 0x56, 0xb4, 0xc2, 0x0a, 0x96, 0xf9, 0x9b, 0x69, 0x7d, 0xae,
+	0x9b, 0xfa, 0x6d, 0x24, 0x7f, 0x54, 0xb6, 0xdf, 0xf1, 0xfd, 0x37, 0xae, 0x86, 0xdd, 0x6d, 0x91,
+	0xe5, 0xfe, 0x4a, 0x1f, 0xbb, 0x4e, 0xac, 0xec, 0x50, 0x0f, 0x96, 0x4d, 0x7d, 0xe9, 0x11, 0xd2,
+	0x4f, 0xa0, 0xb8, 0xea, 0xf5, 0xb0, 0x7d, 0xf7, 0x3b, 0xee, 0xd8, 0xcc, 0xa7, 0xcc, 0x3c, 0xf1,
+	0x13, 0xee, 0xe8, 0x02, 0x66, 0xdb, 0x5e, 0xea, 0x5e, 0xda, 0x1d, 0x2b, 0xca, 0x64, 0x99, 0xf1,
+	0x3d, 0x76, 0x23, 0x58, 0x54, 0x42, 0x59, 0x06, 0x3e, 0x2b, 0x22, 0xfa, 0x1c, 0xa0, 0xc5, 0x2d,
+	0xaa, 0xd6, 0xbc, 0xd3, 0x8a, 0xcd, 0x4b, 0xb2, 0x2c, 0x78, 0x11, 0x99, 0x9f, 0x15, 0x65, 0x90,
+	0x5a, 0x37, 0xe1, 0x51, 0x99, 0x2c, 0xe7, 0x67, 0xa9, 0x9b, 0x84, 0x7b, 0x86, 0x2e, 0x20, 0x5b,
+	0x0b, 0xbb, 0xbe, 0x66, 0x4f, 0x0e, 0x8e, 0x02, 0xb5, 0xf8, 0x0e, 0x9e, 0xfc, 0x6f, 0x5c, 0xfa,
+	0x14, 0x88, 0x6b, 0x38, 0x68, 0xed, 0x42, 0x7a, 0x0c, 0xd9, 0x9d, 0xd8, 0x0c, 0x18, 0xa5, 0x0e,
+	0xe0, 0xdb, 0xc9, 0x37, 0x49, 0xf5, 0x67, 0x02, 0xd3, 0x73, 0xdd, 0xac, 0xd4, 0xa5, 0xa6, 0x27,
+	0x40, 0x6e, 0x74, 0xc3, 0x92, 0x83, 0x2b, 0x1c, 0xe1, 0x26, 0x15, 0xd6, 0x62, 0xb7, 0xb5, 0xc6,
+	0x17, 0xc8, 0xf8, 0x1e, 0x07, 0x15, 0xf4, 0x55, 0x8f, 0xc6, 0x30, 0x32, 0xaa, 0x10, 0xb0, 0x13,
+	0xb6, 0x43, 0x63, 0xc4, 0x15, 0xfa, 0x9d, 0x15, 0x7c, 0x84, 0x2e, 0xab, 0x45, 0xd1, 0x6e, 0xa4,
+	0x42, 0x96, 0x79, 0xcd, 0xf7, 0xb8, 0xfa, 0x1a, 0x72, 0x8e, 0x66, 0xd8, 0xd8, 0xfb, 0xae, 0x93,
+	0x83, 0xae, 0x1d, 0x8b, 0x7d, 0xaf, 0xfb, 0x71, 0x16, 0x0f, 0xaa, 0x2f, 0xfc, 0x18, 0xde, 0x88,
+	0x0c, 0xd2, 0x1b, 0xdd, 0x8c, 0x4e, 0x8c, 0x2a, 0x3a, 0xa6, 0xfa, 0x1e, 0xe6, 0x17, 0x43, 0xb3,
+	0x91, 0x6b, 0x61, 0xa5, 0x56, 0xae, 0x92, 0xd5, 0x5b, 0xb9, 0x1e, 0xeb, 0x7b, 0xb0, 0x4f, 0x9f,
+	0x3c, 0x4a, 0xcf, 0x21, 0xfd, 0x4d, 0xcb, 0xb6, 0x7a, 0x0d, 0xd9, 0x2f, 0x03, 0x06, 0x47, 0x2a,
+	0xd1, 0x8d, 0xfd, 0xf9, 0xd8, 0x71, 0x46, 0xfe, 0x11, 0x94, 0x26, 0xdc, 0xc7, 0xce, 0x0e, 0x5b,
+	0x31, 0x18, 0x6c, 0x47, 0x47, 0x07, 0x54, 0x7d, 0x09, 0x85, 0x2f, 0xe4, 0xdb, 0xfe, 0x0c, 0xf2,
+	0x5b, 0x07, 0xc6, 0xc6, 0xf3, 0xda, 0x9f, 0xf1, 0xc8, 0x56, 0x7f, 0x27, 0x30, 0x7f, 0xab, 0xef,
+	0x90, 0xe3, 0xed, 0x80, 0xc6, 0x3f, 0x13, 0xa3, 0x87, 0x7e, 0x3d, 0x5e, 0x1f, 0x11, 0x2d, 0x61,
+	0xde, 0xa2, 0xb1, 0x52, 0xf9, 0x21, 0xa3, 0x4a, 0x87, 0x14, 0x7d, 0x1a, 0x5e, 0x2a, 0xf1, 0xf6,
+	0x73, 0xa1, 0x53, 0x42, 0x5c, 0x5a, 0xec, 0xe3, 0xdb, 0x0a, 0xc0, 0xdd, 0xd0, 0xe0, 0xa5, 0xee,
+	0xc7, 0x1d, 0x45, 0xe4, 0xbe, 0xde, 0xc8, 0x4e, 0x5a, 0xff, 0x8a, 0x08, 0x0f, 0xa0, 0x5a, 0x41,
+	0xc1, 0x85, 0xc5, 0x37, 0x0e, 0x7c, 0x48, 0x99, 0x5e, 0xd8, 0xa0, 0x4c, 0xc2, 0x7d, 0xec, 0x4a,
+	0x35, 0x43, 0x6f, 0xac, 0x17, 0x86, 0xf0, 0x00, 0xaa, 0xe7, 0x90, 0xbd, 0xd2, 0x83, 0xf2, 0x0e,
+	0x58, 0xbb, 0xc0, 0xd7, 0x21, 0x3c, 0x80, 0xb3, 0x7f, 0x12, 0x48, 0xcf, 0x75, 0x63, 0xe8, 0xc7,
+	0x90, 0x5e, 0x0c, 0xe6, 0x9a, 0xce, 0xea, 0xb8, 0xfb, 0xc5, 0xb4, 0x8e, 0xbf, 0x46, 0xcc, 0xb9,
+	0xa8, 0xd3, 0x77, 0x48, 0x47, 0x6a, 0x91, 0xd5, 0x6e, 0x7b, 0xee, 0xe4, 0x95, 0x50, 0x6b, 0xdc,
+	0x3c, 0x3a, 0x39, 0x06, 0xf2, 0x1a, 0x2d, 0x75, 0xbf, 0x5d, 0x8b, 0x59, 0x3d, 0xbe, 0x8a, 0x67,
+	0x90, 0xbd, 0x7c, 0x2f, 0x64, 0xe4, 0xa7, 0x75, 0x34, 0x67, 0x09, 0x53, 0xef, 0x25, 0x73, 0x4d,
+	0x8f, 0xea, 0x03, 0x57, 0xed, 0x5b, 0x38, 0xfb, 0x37, 0x81, 0xec, 0x65, 0xdb, 0x49, 0x45, 0x5f,
+	0x00, 0x38, 0xc6, 0xef, 0xd3, 0xd0, 0x70, 0xdb, 0x02, 0xea, 0xfb, 0xdd, 0x9f, 0x42, 0x76, 0x31,
+	0xf4, 0x57, 0x48, 0xe3, 0xd2, 0x17, 0x79, 0x1d, 0x04, 0x38, 0x85, 0xfc, 0x07, 0xdc, 0xa0, 0xbd,
+	0x3f, 0x89, 0xbd, 0x7e, 0x0a, 0xa9, 0x33, 0x03, 0x3d, 0xaa, 0x0f, 0x3c, 0xb1, 0x4f, 0x3b, 0x81,
+	0xec, 0xc2, 0x59, 0xec, 0x61, 0xd6, 0x69, 0x78, 0x5b, 0xdd, 0xa3, 0x83, 0xcf, 0xe1, 0xe8, 0x57,
+	0xb4, 0xf7, 0xfb, 0x83, 0x7a, 0x1f, 0xc7, 0x4f, 0x9a, 0xdc, 0xff, 0x0d, 0x7c, 0xf5, 0xdf, 0x00,
+	0x22, 0xbd, 0x49, 0xfa, 0x13, 0x06, 0x00, 0x00,
 }
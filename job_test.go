@@ -23,3 +23,15 @@ func TestSetDefaults(t *testing.T) {
 		t.Error("job.When should be now")
 	}
 }
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+	j := &Job{Content: "test", Metadata: map[string]string{"foo": "bar"}}
+	out := decode(j.String())
+	if out.ID != j.ID || out.Content != "test" || out.Metadata["foo"] != "bar" || !out.When.Equal(j.When) {
+		t.Errorf("decoding failed %v != %v", out, j)
+	}
+	if out := decode(compress("legacy")); out.Content != "legacy" {
+		t.Errorf("decoding of a legacy value failed %s != \"legacy\"", out.Content)
+	}
+}
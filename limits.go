@@ -0,0 +1,49 @@
+package airq
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// SetConcurrencyLimit allows at most n reserved jobs at once among the jobs
+// sharing the same values of attrs, for example 3 exports per tenant with
+// SetConcurrencyLimit(3, "type", "tenant"). The attributes are "type",
+// "group", "tenant" or keys of the metadata of the jobs, jobs missing one of
+// them aren't limited. "tenant" is the Tenant of a job, or its "tenant"
+// metadata when it has none as before tenants were fields of the jobs. Jobs
+// over the limit stay queued. The limit applies at once to running consumers,
+// a limit of 0 removes it.
+func (q *Queue) SetConcurrencyLimit(n int, attrs ...string) error {
+	if len(attrs) == 0 {
+		return fmt.Errorf("no attribute provided")
+	}
+	for _, attr := range attrs {
+		if attr == "" || strings.Contains(attr, ",") {
+			return fmt.Errorf("invalid attribute %q", attr)
+		}
+	}
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	field := strings.Join(attrs, ",")
+	var err error
+	if n <= 0 {
+		_, err = c.Do("HDEL", q.key()+":limits", field)
+	} else {
+		_, err = c.Do("HSET", q.key()+":limits", field, n)
+	}
+	return err
+}
+
+// ConcurrencyLimits returns the concurrency limits of the queue by attributes
+// joined with commas, such as "type,tenant".
+func (q *Queue) ConcurrencyLimits() (map[string]int, error) {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	return redis.IntMap(c.Do("HGETALL", q.key()+":limits"))
+}
@@ -0,0 +1,66 @@
+package airq
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConcurrencyLimit(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	if err := q.SetConcurrencyLimit(2, "type", "tenant"); err != nil {
+		t.Error(err)
+	}
+	if limits, _ := q.ConcurrencyLimits(); !reflect.DeepEqual(limits, map[string]int{"type,tenant": 2}) {
+		t.Error("Expected a limit of 2 by type and tenant, got", limits)
+	}
+	for i := 0; i < 3; i++ {
+		q.Push(
+			&Job{Content: randomName(), Type: "export", Tenant: "a"},
+			&Job{Content: randomName(), Type: "export", Tenant: "b"},
+		)
+	}
+	q.Push(&Job{Content: "unlimited", Type: "export"})
+
+	jobs, _ := q.Reserve(10)
+	if len(jobs) != 5 {
+		t.Error("Expected 2 exports by tenant and the unlimited job, got", len(jobs))
+		t.FailNow()
+	}
+	if more, _ := q.Reserve(10); len(more) != 0 {
+		t.Error("Expected the jobs over the limit to stay queued, got", len(more))
+	}
+	if stats, _ := q.Stats(); stats.Due != 2 {
+		t.Error("Expected 2 jobs left in the queue, got", stats)
+	}
+
+	for _, j := range jobs {
+		if j.Tenant != "" {
+			q.Ack(j.ID)
+			break
+		}
+	}
+	if more, _ := q.Reserve(10); len(more) != 1 {
+		t.Error("Expected a job to run once another is done, got", len(more))
+	}
+
+	q.SetConcurrencyLimit(0, "type", "tenant")
+	if more, _ := q.Reserve(10); len(more) != 1 {
+		t.Error("Expected the last job once the limit is removed, got", len(more))
+	}
+}
+
+func TestConcurrencyLimitTenantMetadata(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	q.SetConcurrencyLimit(1, "tenant")
+	q.Push(
+		&Job{Content: "1", Metadata: map[string]string{"tenant": "a"}},
+		&Job{Content: "2", Metadata: map[string]string{"tenant": "a"}},
+	)
+	if jobs, _ := q.Reserve(10); len(jobs) != 1 {
+		t.Error("Expected the tenant metadata to be limited, got", jobs)
+	}
+}
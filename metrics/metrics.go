@@ -0,0 +1,170 @@
+// Package metrics exports the activity of airq queues to prometheus.
+//
+//	c := metrics.New()
+//	prometheus.MustRegister(c)
+//	q := airq.New("queue_name", airq.WithPool(pool), airq.WithObserver(c))
+//	c.Watch(q)
+//
+// Every metric is labelled by namespace and queue name. Jobs pushed and removed through a
+// server.Server are counted by the observer of its queue.
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/missena-corp/airq"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector counts the jobs going through the queues it observes and reports
+// the depth of the queues it watches.
+type Collector struct {
+	pushed     *prometheus.CounterVec
+	popped     *prometheus.CounterVec
+	removed    *prometheus.CounterVec
+	failed     *prometheus.CounterVec
+	retried    *prometheus.CounterVec
+	processing *prometheus.HistogramVec
+	latency    *prometheus.HistogramVec
+	handled    *prometheus.HistogramVec
+	due        *prometheus.Desc
+	scheduled  *prometheus.Desc
+
+	mu     sync.Mutex
+	queues map[queueKey]*airq.Queue
+}
+
+// queueKey identifies a watched queue, queues of different namespaces can
+// share a name.
+type queueKey struct{ namespace, name string }
+
+// New returns a Collector, it has to be registered to prometheus.
+func New() *Collector {
+	counter := func(name, help string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "airq",
+			Name:      name,
+			Help:      help,
+		}, []string{"namespace", "queue"})
+	}
+	histogram := func(name, help string) *prometheus.HistogramVec {
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "airq",
+			Name:      name,
+			Help:      help,
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"namespace", "queue"})
+	}
+	return &Collector{
+		pushed:     counter("jobs_pushed_total", "Jobs pushed to the queue."),
+		popped:     counter("jobs_popped_total", "Jobs popped or reserved from the queue."),
+		removed:    counter("jobs_removed_total", "Jobs removed from the queue."),
+		failed:     counter("jobs_failed_total", "Reserved jobs which failed."),
+		retried:    counter("jobs_retried_total", "Jobs scheduled again after a failure or an expired lease."),
+		processing: histogram("job_processing_seconds", "Time spent processing popped jobs."),
+		latency:    histogram("job_latency_seconds", "Time between the due date of a job and its pop."),
+		handled: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "airq",
+			Name:      "job_handler_seconds",
+			Help:      "Time spent in the handlers of jobs by type and status.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"namespace", "queue", "type", "status"}),
+		due: prometheus.NewDesc(
+			"airq_jobs_due", "Jobs ready to be popped.", []string{"namespace", "queue"}, nil,
+		),
+		scheduled: prometheus.NewDesc(
+			"airq_jobs_scheduled", "Jobs scheduled in the future.", []string{"namespace", "queue"}, nil,
+		),
+		queues: make(map[queueKey]*airq.Queue),
+	}
+}
+
+// Observe implements airq.Observer.
+func (c *Collector) Observe(e *airq.Event) {
+	n := float64(len(e.Jobs))
+	switch e.Kind {
+	case airq.EventPush:
+		c.pushed.WithLabelValues(e.Namespace, e.Queue).Add(n)
+	case airq.EventPop:
+		c.popped.WithLabelValues(e.Namespace, e.Queue).Add(n)
+		now := time.Now()
+		for _, j := range e.Jobs {
+			c.latency.WithLabelValues(e.Namespace, e.Queue).Observe(now.Sub(j.When).Seconds())
+		}
+	case airq.EventRemove:
+		c.removed.WithLabelValues(e.Namespace, e.Queue).Add(n)
+	case airq.EventFail:
+		c.failed.WithLabelValues(e.Namespace, e.Queue).Add(n)
+	case airq.EventRetry:
+		c.retried.WithLabelValues(e.Namespace, e.Queue).Add(n)
+	case airq.EventProcess:
+		c.processing.WithLabelValues(e.Namespace, e.Queue).Observe(e.Duration.Seconds())
+	}
+}
+
+// Middleware times the handler of the jobs of q by job type and status: "ok",
+// "error" or "permanent" for errors marked with airq.Permanent.
+func (c *Collector) Middleware(q *airq.Queue) airq.Middleware {
+	return func(next airq.Handler) airq.Handler {
+		return airq.HandlerFunc(func(ctx context.Context, j *airq.Job) error {
+			start := time.Now()
+			err := next.Process(ctx, j)
+			status := "ok"
+			if airq.IsPermanent(err) {
+				status = "permanent"
+			} else if err != nil {
+				status = "error"
+			}
+			c.handled.WithLabelValues(q.Namespace, q.Name, j.Type, status).Observe(time.Since(start).Seconds())
+			return err
+		})
+	}
+}
+
+// Watch reports the due and scheduled depth of q, read with q.Stats on every
+// collection.
+func (c *Collector) Watch(q *airq.Queue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queues[queueKey{q.Namespace, q.Name}] = q
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.pushed.Describe(ch)
+	c.popped.Describe(ch)
+	c.removed.Describe(ch)
+	c.failed.Describe(ch)
+	c.retried.Describe(ch)
+	c.processing.Describe(ch)
+	c.latency.Describe(ch)
+	c.handled.Describe(ch)
+	ch <- c.due
+	ch <- c.scheduled
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.pushed.Collect(ch)
+	c.popped.Collect(ch)
+	c.removed.Collect(ch)
+	c.failed.Collect(ch)
+	c.retried.Collect(ch)
+	c.processing.Collect(ch)
+	c.latency.Collect(ch)
+	c.handled.Collect(ch)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, q := range c.queues {
+		stats, err := q.Stats()
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(c.due, err)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.due, prometheus.GaugeValue, float64(stats.Due), key.namespace, key.name)
+		ch <- prometheus.MustNewConstMetric(c.scheduled, prometheus.GaugeValue, float64(stats.Scheduled), key.namespace, key.name)
+	}
+}
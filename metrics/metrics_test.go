@@ -0,0 +1,142 @@
+package metrics_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/missena-corp/airq"
+	"github.com/missena-corp/airq/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func randomName() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+func TestCollector(t *testing.T) {
+	c, err := redis.Dial("tcp", "127.0.0.1:6379")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	defer c.Close()
+	collector := metrics.New()
+	q := airq.New(randomName(), airq.WithConn(c), airq.WithObserver(collector))
+	defer q.Delete()
+	collector.Watch(q)
+
+	q.Push(
+		&airq.Job{Content: "popped", When: time.Now().Add(-time.Second)},
+		&airq.Job{Content: "removed", ID: "removed"},
+		&airq.Job{Content: "failed", ID: "failed"},
+		&airq.Job{Content: "scheduled", When: time.Now().Add(time.Hour)},
+	)
+	q.Pop()
+	q.Remove("removed")
+	q.Reserve(1)
+	q.Fail("failed", errors.New("boom"))
+
+	expected := `
+# HELP airq_jobs_pushed_total Jobs pushed to the queue.
+# TYPE airq_jobs_pushed_total counter
+airq_jobs_pushed_total{namespace="",queue="QUEUE"} 4
+# HELP airq_jobs_popped_total Jobs popped or reserved from the queue.
+# TYPE airq_jobs_popped_total counter
+airq_jobs_popped_total{namespace="",queue="QUEUE"} 2
+# HELP airq_jobs_removed_total Jobs removed from the queue.
+# TYPE airq_jobs_removed_total counter
+airq_jobs_removed_total{namespace="",queue="QUEUE"} 1
+# HELP airq_jobs_failed_total Reserved jobs which failed.
+# TYPE airq_jobs_failed_total counter
+airq_jobs_failed_total{namespace="",queue="QUEUE"} 1
+# HELP airq_jobs_retried_total Jobs scheduled again after a failure or an expired lease.
+# TYPE airq_jobs_retried_total counter
+airq_jobs_retried_total{namespace="",queue="QUEUE"} 1
+# HELP airq_jobs_due Jobs ready to be popped.
+# TYPE airq_jobs_due gauge
+airq_jobs_due{namespace="",queue="QUEUE"} 0
+# HELP airq_jobs_scheduled Jobs scheduled in the future.
+# TYPE airq_jobs_scheduled gauge
+airq_jobs_scheduled{namespace="",queue="QUEUE"} 2
+`
+	if err := testutil.CollectAndCompare(
+		collector,
+		strings.NewReader(strings.Replace(expected, "QUEUE", q.Name, -1)),
+		"airq_jobs_pushed_total",
+		"airq_jobs_popped_total",
+		"airq_jobs_removed_total",
+		"airq_jobs_failed_total",
+		"airq_jobs_retried_total",
+		"airq_jobs_due",
+		"airq_jobs_scheduled",
+	); err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(collector, "airq_job_latency_seconds"); n != 1 {
+		t.Error("Expected the latency of the popped jobs to be observed, got", n)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	collector := metrics.New()
+	q := airq.New(randomName())
+	h := airq.Chain(airq.HandlerFunc(func(ctx context.Context, j *airq.Job) error {
+		if j.Content == "fatal" {
+			return airq.Permanent(errors.New("fatal"))
+		}
+		return nil
+	}), collector.Middleware(q))
+
+	h.Process(context.Background(), &airq.Job{Type: "email"})
+	h.Process(context.Background(), &airq.Job{Type: "email"})
+	h.Process(context.Background(), &airq.Job{Type: "sms", Content: "fatal"})
+
+	if n := testutil.CollectAndCount(collector, "airq_job_handler_seconds"); n != 2 {
+		t.Error("Expected a series by type and status, got", n)
+	}
+}
+
+func TestCollectorNamespaces(t *testing.T) {
+	c, err := redis.Dial("tcp", "127.0.0.1:6379")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	defer c.Close()
+	collector := metrics.New()
+	name := randomName()
+	a := airq.New(name, airq.WithConn(c), airq.WithNamespace("a"), airq.WithObserver(collector))
+	b := airq.New(name, airq.WithConn(c), airq.WithNamespace("b"), airq.WithObserver(collector))
+	defer a.Delete()
+	defer b.Delete()
+	collector.Watch(a)
+	collector.Watch(b)
+
+	a.Push(&airq.Job{Content: "1"})
+	b.Push(&airq.Job{Content: "1"}, &airq.Job{Content: "2"})
+
+	expected := `
+# HELP airq_jobs_pushed_total Jobs pushed to the queue.
+# TYPE airq_jobs_pushed_total counter
+airq_jobs_pushed_total{namespace="a",queue="QUEUE"} 1
+airq_jobs_pushed_total{namespace="b",queue="QUEUE"} 2
+`
+	if err := testutil.CollectAndCompare(
+		collector,
+		strings.NewReader(strings.Replace(expected, "QUEUE", name, -1)),
+		"airq_jobs_pushed_total",
+	); err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(collector, "airq_jobs_due"); n != 2 {
+		t.Error("Expected the depth of both queues, got", n)
+	}
+}
@@ -0,0 +1,110 @@
+package airq
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Middleware wraps a Handler to add a behaviour around the processing of jobs.
+type Middleware func(Handler) Handler
+
+// Chain wraps h with the middlewares, the first one being the outermost.
+func Chain(h Handler, mws ...Middleware) Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// Recover turns a panic of the handler into an error, the job is then failed
+// instead of the worker crashing.
+func Recover(next Handler) Handler {
+	return HandlerFunc(func(ctx context.Context, j *Job) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic processing job %s: %v\n%s", j.ID, r, debug.Stack())
+			}
+		}()
+		return next.Process(ctx, j)
+	})
+}
+
+// Timeout cancels the context of the handler after d.
+func Timeout(d time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, j *Job) error {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			return next.Process(ctx, j)
+		})
+	}
+}
+
+// Logger logs the outcome of every job with l.
+func Logger(l *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, j *Job) error {
+			start := time.Now()
+			err := next.Process(ctx, j)
+			attrs := []any{
+				slog.String("id", j.ID),
+				slog.String("type", j.Type),
+				slog.Int("attempts", j.Attempts),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if err != nil {
+				l.ErrorContext(ctx, "job failed", append(attrs, slog.Any("error", err))...)
+			} else {
+				l.InfoContext(ctx, "job done", attrs...)
+			}
+			return err
+		})
+	}
+}
+
+// Trace processes every job in the consumer span started by q.StartSpan.
+func Trace(q *Queue) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, j *Job) error {
+			ctx, span := q.StartSpan(ctx, j)
+			defer span.End()
+			err := next.Process(ctx, j)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return err
+		})
+	}
+}
+
+// Idempotent skips the jobs of q already processed successfully during the
+// last ttl, such as a job delivered again because its lease expired right
+// after it was done.
+func Idempotent(q *Queue, ttl time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, j *Job) error {
+			c, managed := q.conn()
+			if managed {
+				defer c.Close()
+			}
+			key := q.key() + ":done:" + j.ID
+			done, err := redis.Bool(c.Do("EXISTS", key))
+			if err != nil || done {
+				return err
+			}
+			if err = next.Process(ctx, j); err != nil {
+				return err
+			}
+			// the job is done even if it can't be marked so
+			c.Do("SET", key, 1, "PX", ttl.Milliseconds())
+			return nil
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package airq
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestChain(t *testing.T) {
+	calls := []string{}
+	mw := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return HandlerFunc(func(ctx context.Context, j *Job) error {
+				calls = append(calls, name)
+				return next.Process(ctx, j)
+			})
+		}
+	}
+	h := Chain(HandlerFunc(func(ctx context.Context, j *Job) error {
+		calls = append(calls, "handler")
+		return nil
+	}), mw("first"), mw("second"))
+	h.Process(context.Background(), &Job{})
+	if expected := []string{"first", "second", "handler"}; !reflect.DeepEqual(calls, expected) {
+		t.Error("Expected middlewares to be called in order", expected, "but got", calls)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	addJobs(t, q, []Job{Job{Content: "panic"}})
+	h := Chain(HandlerFunc(func(ctx context.Context, j *Job) error {
+		panic(j.Content)
+	}), Recover)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	q.Work(ctx, h, &LoopOptions{Sleep: 10 * time.Millisecond})
+
+	if stats, _ := q.Stats(); stats.Scheduled != 1 || stats.InFlight != 0 {
+		t.Error("Expected the panicking job to be retried, got", stats)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	h := Chain(HandlerFunc(func(ctx context.Context, j *Job) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}), Timeout(10*time.Millisecond))
+	if err := h.Process(context.Background(), &Job{}); err != context.DeadlineExceeded {
+		t.Error("Expected the handler to time out, got", err)
+	}
+}
+
+func TestIdempotent(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	n := 0
+	h := Chain(HandlerFunc(func(ctx context.Context, j *Job) error {
+		n++
+		return nil
+	}), Idempotent(q, time.Minute))
+	j := &Job{ID: randomName()}
+	h.Process(context.Background(), j)
+	h.Process(context.Background(), j)
+	if n != 1 {
+		t.Error("Expected the job to be processed once, got", n)
+	}
+}
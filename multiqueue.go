@@ -0,0 +1,173 @@
+package airq
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// MultiQueue reserves jobs across several queues. Each reservation is shared
+// between the queues by weight, or by strict priority, and the share a queue
+// can't fill is taken from the other queues so that no poll is wasted on an
+// empty queue. Queues sharing the same Pool or Conn are reserved from in a
+// single script call.
+type MultiQueue struct {
+	// Strict serves the queues in the order they were added: a queue only gets
+	// the jobs the queues before it couldn't provide.
+	Strict  bool
+	queues  []*Queue
+	weights []int
+}
+
+// NewMultiQueue returns a MultiQueue over queues of weight 1.
+func NewMultiQueue(queues ...*Queue) *MultiQueue {
+	m := new(MultiQueue)
+	for _, q := range queues {
+		m.Add(q, 1)
+	}
+	return m
+}
+
+// Add adds a queue to m. Its share of each reservation is its weight over the
+// sum of the weights, a weight below 1 counts as 1.
+func (m *MultiQueue) Add(q *Queue, weight int) *MultiQueue {
+	if weight < 1 {
+		weight = 1
+	}
+	m.queues = append(m.queues, q)
+	m.weights = append(m.weights, weight)
+	return m
+}
+
+// Queue returns the queue which reserved the job, nil if it wasn't reserved.
+func (j *Job) Queue() *Queue { return j.queue }
+
+// Reserve reserves up to limit jobs across the queues like Queue.Reserve, the
+// queue of each job is given by Job.Queue.
+func (m *MultiQueue) Reserve(limit int) ([]*Job, error) {
+	if limit == 0 {
+		return []*Job{}, fmt.Errorf("limit 0")
+	}
+	if len(m.queues) == 0 {
+		return []*Job{}, fmt.Errorf("no queue provided")
+	}
+	order, quotas := m.plan(limit)
+	if !m.shared() {
+		return m.reserveEach(limit, order, quotas)
+	}
+	c, managed := m.queues[0].conn()
+	if managed {
+		defer c.Close()
+	}
+	keysAndArgs := redis.Args{len(order)}
+	for _, i := range order {
+		keysAndArgs = keysAndArgs.Add(m.queues[i].key())
+	}
+	keysAndArgs = keysAndArgs.Add(time.Now().UnixNano(), limit)
+	for _, i := range order {
+		q := m.queues[i]
+		keysAndArgs = append(append(keysAndArgs, q.trackArgs()...), q.reserveArgs()...).Add(quotas[i])
+	}
+	replies, err := redis.Values(multiReserveScript.Do(c, keysAndArgs...))
+	if err != nil {
+		return nil, err
+	}
+	jobs := []*Job{}
+	for n, reply := range replies {
+		values, err := redis.Values(reply, nil)
+		if err != nil {
+			return nil, err
+		}
+		reserved, err := m.queues[order[n]].reserved(values)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, reserved...)
+	}
+	return jobs, nil
+}
+
+// Work reserves jobs across the queues and processes them with h until ctx is
+// done, see Queue.Work.
+func (m *MultiQueue) Work(ctx context.Context, h Handler, opts *LoopOptions) error {
+	pooled := true
+	for _, q := range m.queues {
+		pooled = pooled && q.Pool != nil
+	}
+	return work(ctx, m.Reserve, h, opts, pooled)
+}
+
+// plan returns the order in which the queues are served and their quota of
+// limit jobs. The quotas follow the weights, the jobs left by the rounding go
+// to the first queues of an order drawn at random by weight.
+func (m *MultiQueue) plan(limit int) ([]int, []int) {
+	order, quotas := make([]int, len(m.queues)), make([]int, len(m.queues))
+	for i := range order {
+		order[i] = i
+	}
+	if m.Strict {
+		quotas[0] = limit
+		return order, quotas
+	}
+	keys, total := make([]float64, len(m.queues)), 0
+	for i, w := range m.weights {
+		keys[i] = -rand.ExpFloat64() / float64(w)
+		total += w
+	}
+	sort.Slice(order, func(a, b int) bool { return keys[order[a]] > keys[order[b]] })
+	left := limit
+	for i, w := range m.weights {
+		quotas[i] = limit * w / total
+		left -= quotas[i]
+	}
+	for _, i := range order[:left] {
+		quotas[i]++
+	}
+	return order, quotas
+}
+
+// shared tells whether the queues share their connection.
+func (m *MultiQueue) shared() bool {
+	first := m.queues[0]
+	for _, q := range m.queues[1:] {
+		if q.Pool != first.Pool || (q.Pool == nil && q.Conn != first.Conn) {
+			return false
+		}
+	}
+	return true
+}
+
+// reserveEach reserves jobs from each queue in turn, the way the single script
+// call does for queues sharing their connection.
+func (m *MultiQueue) reserveEach(limit int, order, quotas []int) ([]*Job, error) {
+	jobs, full := []*Job{}, make([]bool, len(m.queues))
+	for _, i := range order {
+		if quotas[i] == 0 {
+			full[i] = true
+			continue
+		}
+		reserved, err := m.queues[i].Reserve(quotas[i])
+		if err != nil {
+			return jobs, err
+		}
+		jobs, full[i] = append(jobs, reserved...), len(reserved) >= quotas[i]
+	}
+	for _, i := range order {
+		if len(jobs) >= limit {
+			break
+		}
+		if !full[i] {
+			continue
+		}
+		reserved, err := m.queues[i].Reserve(limit - len(jobs))
+		if err != nil {
+			return jobs, err
+		}
+		jobs = append(jobs, reserved...)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,81 @@
+package airq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+func addMany(t *testing.T, q *Queue, n int) {
+	for i := 0; i < n; i++ {
+		addJobs(t, q, []Job{Job{Content: q.Name, Unique: true, When: time.Now().Add(-time.Second)}})
+	}
+}
+
+func countByQueue(jobs []*Job) map[*Queue]int {
+	counts := map[*Queue]int{}
+	for _, j := range jobs {
+		counts[j.Queue()]++
+	}
+	return counts
+}
+
+func TestMultiQueue(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+	low, mid := q.Sibling(randomName()), q.Sibling(randomName())
+	defer low.Delete()
+	defer mid.Delete()
+	addMany(t, q, 20)
+	addMany(t, mid, 20)
+	addMany(t, low, 3)
+
+	m := new(MultiQueue).Add(q, 7).Add(mid, 2).Add(low, 1)
+	jobs, err := m.Reserve(10)
+	if err != nil {
+		t.Error(err)
+	}
+	if counts := countByQueue(jobs); counts[q] != 7 || counts[mid] != 2 || counts[low] != 1 {
+		t.Error("Expected a 7/2/1 split, got", counts)
+	}
+	for _, j := range jobs {
+		if j.Content != j.Queue().Name {
+			t.Error("Expected job", j.ID, "to come from", j.Content, "got", j.Queue().Name)
+		}
+	}
+	// the shares of the drained queue are taken from the others
+	jobs, _ = m.Reserve(20)
+	if counts := countByQueue(jobs); counts[low] != 2 || counts[q]+counts[mid] != 18 {
+		t.Error("Expected the 2 jobs left in low and 18 others, got", counts)
+	}
+}
+
+func TestMultiQueueStrict(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+	c, err := redis.Dial("tcp", "127.0.0.1:6379")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	defer c.Close()
+	other := New(randomName(), WithConn(c))
+	defer other.Delete()
+	addMany(t, q, 2)
+	addMany(t, other, 5)
+
+	// the queues don't share their connection and are reserved from in turn
+	m := NewMultiQueue(q, other)
+	m.Strict = true
+	jobs, err := m.Reserve(4)
+	if err != nil {
+		t.Error(err)
+	}
+	if counts := countByQueue(jobs); counts[q] != 2 || counts[other] != 2 {
+		t.Error("Expected the 2 jobs of the first queue then 2 of the other, got", counts)
+	}
+	if err := jobs[0].Queue().Ack(jobs[0].ID); err != nil {
+		t.Error(err)
+	}
+}
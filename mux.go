@@ -0,0 +1,46 @@
+package airq
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Mux is a Handler dispatching jobs to the handler registered for their Type.
+type Mux struct {
+	// Fallback processes the jobs of unregistered types, they are buried when
+	// it is nil.
+	Fallback Handler
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+// NewMux returns an empty Mux.
+func NewMux() *Mux { return &Mux{handlers: make(map[string]Handler)} }
+
+// Handle registers the handler of the jobs of type typ.
+func (m *Mux) Handle(typ string, h Handler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers[typ] = h
+}
+
+// HandleFunc registers the handler function of the jobs of type typ.
+func (m *Mux) HandleFunc(typ string, f func(ctx context.Context, j *Job) error) {
+	m.Handle(typ, HandlerFunc(f))
+}
+
+// Process dispatches j to the handler of its type.
+func (m *Mux) Process(ctx context.Context, j *Job) error {
+	m.mu.RLock()
+	h, ok := m.handlers[j.Type]
+	m.mu.RUnlock()
+	switch {
+	case ok:
+		return h.Process(ctx, j)
+	case m.Fallback != nil:
+		return m.Fallback.Process(ctx, j)
+	}
+	return Permanent(fmt.Errorf("no handler for job type %q", j.Type))
+}
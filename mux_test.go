@@ -0,0 +1,58 @@
+package airq
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestMux(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	addJobs(t, q, []Job{
+		Job{Content: "a", Type: "email"},
+		Job{Content: "b", Type: "sms"},
+		Job{Content: "c", Type: "fax"},
+	})
+
+	handled := []string{}
+	record := func(ctx context.Context, j *Job) error {
+		handled = append(handled, j.Type+":"+j.Content)
+		return nil
+	}
+	mux := NewMux()
+	mux.HandleFunc("email", record)
+	mux.HandleFunc("sms", record)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	q.Work(ctx, mux, &LoopOptions{Sleep: 10 * time.Millisecond})
+
+	sort.Strings(handled)
+	if expected := []string{"email:a", "sms:b"}; !reflect.DeepEqual(handled, expected) {
+		t.Error("Expected jobs to be dispatched by type", expected, "but got", handled)
+	}
+	if stats, _ := q.Stats(); stats.Dead != 1 {
+		t.Error("Expected the job of unknown type to be dead, got", stats)
+	}
+}
+
+func TestMuxFallback(t *testing.T) {
+	var fallback *Job
+	mux := NewMux()
+	mux.Fallback = HandlerFunc(func(ctx context.Context, j *Job) error {
+		fallback = j
+		return nil
+	})
+	j := &Job{Type: "unknown"}
+	if err := mux.Process(context.Background(), j); err != nil || fallback != j {
+		t.Error("Expected the job to be processed by the fallback, got", err)
+	}
+	mux.Fallback = nil
+	if err := mux.Process(context.Background(), j); !IsPermanent(err) {
+		t.Error("Expected a permanent error without fallback, got", err)
+	}
+}
@@ -0,0 +1,69 @@
+package airq
+
+import "time"
+
+// EventKind is the kind of operation an Event describes.
+type EventKind string
+
+const (
+	EventPush    EventKind = "push"
+	EventPop     EventKind = "pop"
+	EventRemove  EventKind = "remove"
+	EventAck     EventKind = "ack"
+	EventFail    EventKind = "fail"
+	EventRetry   EventKind = "retry"
+	EventDead    EventKind = "dead"
+	EventProcess EventKind = "process"
+)
+
+// Event describes an operation done on a queue.
+type Event struct {
+	Kind      EventKind
+	Namespace string
+	Queue     string
+	Time      time.Time
+	// Jobs concerned by the operation, only their ID is known for removals,
+	// acknowledgements, failures, retries and deaths.
+	Jobs []*Job
+	// Duration is the time spent processing the jobs of an EventProcess.
+	Duration time.Duration
+	Err      error
+}
+
+// IDs returns the ids of the jobs of the event.
+func (e *Event) IDs() []string {
+	ids := make([]string, len(e.Jobs))
+	for i, j := range e.Jobs {
+		ids[i] = j.ID
+	}
+	return ids
+}
+
+// Observer is notified of the operations done on a queue, see the metrics
+// package for an example.
+type Observer interface {
+	Observe(*Event)
+}
+
+// WithObserver adds an observer to the queue.
+func WithObserver(o Observer) Option {
+	return func(q *Queue) { q.observers = append(q.observers, o) }
+}
+
+func (q *Queue) notify(kind EventKind, jobs []*Job, d time.Duration, err error) {
+	if len(q.observers) == 0 || (len(jobs) == 0 && kind != EventProcess) {
+		return
+	}
+	e := &Event{Kind: kind, Namespace: q.Namespace, Queue: q.Name, Time: time.Now(), Jobs: jobs, Duration: d, Err: err}
+	for _, o := range q.observers {
+		o.Observe(e)
+	}
+}
+
+func idJobs(ids ...string) []*Job {
+	jobs := make([]*Job, len(ids))
+	for i, id := range ids {
+		jobs[i] = &Job{ID: id}
+	}
+	return jobs
+}
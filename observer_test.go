@@ -0,0 +1,35 @@
+package airq
+
+import (
+	"reflect"
+	"testing"
+)
+
+type recorder []*Event
+
+func (r *recorder) Observe(e *Event) { *r = append(*r, e) }
+
+func TestObserver(t *testing.T) {
+	r := new(recorder)
+	q, teardown := setup(t, WithObserver(r))
+	defer teardown()
+
+	addJobs(t, q, []Job{Job{Content: "popped", ID: "01"}, Job{Content: "removed", ID: "02"}})
+	q.Pop()
+	q.Remove("02")
+
+	kinds := []EventKind{}
+	for _, e := range *r {
+		kinds = append(kinds, e.Kind)
+		if e.Queue != q.Name {
+			t.Error("Expected events of queue", q.Name, "got", e.Queue)
+		}
+	}
+	expected := []EventKind{EventPush, EventPush, EventPop, EventRemove}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Error("Expected events", expected, "but got", kinds)
+	}
+	if ids := (*r)[2].IDs(); !reflect.DeepEqual(ids, []string{"01"}) {
+		t.Error("Expected to pop job 01, got", ids)
+	}
+}
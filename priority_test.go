@@ -0,0 +1,39 @@
+package airq
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPriority(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	now := time.Now()
+	addJobs(t, q, []Job{
+		Job{Content: "old", When: now.Add(-time.Hour)},
+		Job{Content: "urgent", Priority: 10, When: now.Add(-time.Second)},
+		Job{Content: "later", Priority: 20, When: now.Add(time.Hour)},
+		Job{Content: "low", Priority: -1, When: now.Add(-2 * time.Hour)},
+	})
+	for _, expected := range []string{"urgent", "old", "low", ""} {
+		if job, _ := q.Pop(); job != expected {
+			t.Error("Expected", expected, "but got", job)
+		}
+	}
+}
+
+func TestPriorityAging(t *testing.T) {
+	q, teardown := setup(t, WithPriorityAging(time.Minute))
+	defer teardown()
+
+	now := time.Now()
+	addJobs(t, q, []Job{
+		Job{Content: "urgent", Priority: 10, When: now.Add(-time.Second)},
+		Job{Content: "starving", When: now.Add(-time.Hour)},
+	})
+	jobs, _ := q.Reserve(1)
+	if len(jobs) != 1 || jobs[0].Content != "starving" {
+		t.Error("Expected the aged job first, got", jobs)
+	}
+}
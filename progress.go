@@ -0,0 +1,87 @@
+package airq
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// ErrNotFound is returned when a job, or a batch, is not in the queue.
+var ErrNotFound = errors.New("job not found")
+
+// JobInfo is a job of a queue with the progress it reported while running.
+type JobInfo struct {
+	Job *Job
+	// Progress is the percentage of completion last reported by the job and
+	// Message its description.
+	Progress int
+	Message  string
+	// Deadline is the end of the lease of a job in flight, it is zero for jobs
+	// waiting in the queue.
+	Deadline time.Time
+}
+
+// Progress reports the progress of a reserved job, readable with Queue.Get,
+// and extends its lease like Heartbeat.
+func (j *Job) Progress(pct int, msg string) error {
+	return j.heartbeat(strconv.Itoa(pct) + " " + msg)
+}
+
+// Heartbeat tells that a reserved job is still running, its lease is extended
+// by the lease of its queue so that a slow job isn't delivered again.
+func (j *Job) Heartbeat() error { return j.heartbeat("") }
+
+func (j *Job) heartbeat(progress string) error {
+	q := j.queue
+	if q == nil {
+		return fmt.Errorf("job %s was not reserved", j.ID)
+	}
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	deadline := time.Now().Add(q.lease()).UnixNano()
+	ok, err := redis.Bool(heartbeatScript.Do(c, q.key(), deadline, j.ID, progress))
+	if err == nil && !ok {
+		err = fmt.Errorf("job %s is not in flight in queue %s", j.ID, q.Name)
+	}
+	return err
+}
+
+// Get returns the job id of the queue, whether it is waiting, in flight or
+// dead, or ErrNotFound.
+func (q *Queue) Get(id string) (*JobInfo, error) {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	res, err := redis.Values(getScript.Do(c, q.key(), id))
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, ErrNotFound
+	}
+	var value, when, deadline, progress string
+	var attempts int
+	if _, err = redis.Scan(res, &value, &when, &deadline, &attempts, &progress); err != nil {
+		return nil, err
+	}
+	info := &JobInfo{Job: decode(value)}
+	info.Job.ID, info.Job.Attempts = id, attempts
+	if when != "" {
+		info.Job.When = parseScore(when)
+	}
+	if deadline != "" {
+		info.Deadline = parseScore(deadline)
+	}
+	if pct, msg, ok := strings.Cut(progress, " "); ok {
+		info.Progress, _ = strconv.Atoi(pct)
+		info.Message = msg
+	}
+	return info, nil
+}
@@ -0,0 +1,41 @@
+package airq
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProgress(t *testing.T) {
+	q, teardown := setup(t, WithLease(time.Minute))
+	defer teardown()
+
+	ids, _ := q.Push(&Job{Content: "long"})
+	if info, err := q.Get(ids[0]); err != nil || info.Job.Content != "long" || !info.Deadline.IsZero() {
+		t.Error("Expected to get the pending job, got", info, err)
+	}
+	jobs, _ := q.Reserve(1)
+	if len(jobs) != 1 {
+		t.Error("Expected to reserve the job")
+		t.FailNow()
+	}
+	before, _ := q.Get(ids[0])
+	if err := jobs[0].Progress(40, "step 3/7"); err != nil {
+		t.Error(err)
+	}
+	info, err := q.Get(ids[0])
+	if err != nil || info.Progress != 40 || info.Message != "step 3/7" || info.Job.Attempts != 1 {
+		t.Error("Expected the progress of the job, got", info, err)
+		t.FailNow()
+	}
+	if !info.Deadline.After(before.Deadline) {
+		t.Error("Expected the lease to be extended, got", before.Deadline, info.Deadline)
+	}
+
+	q.Ack(ids[0])
+	if err := jobs[0].Heartbeat(); err == nil {
+		t.Error("Expected an error on the heartbeat of a done job")
+	}
+	if _, err := q.Get(ids[0]); err != ErrNotFound {
+		t.Error("Expected the done job not to be found, got", err)
+	}
+}
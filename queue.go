@@ -1,22 +1,61 @@
 package airq
 
 import (
+	"context"
 	"fmt"
+	"runtime/debug"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Queue holds a reference to a redis connection and a queue name.
 type Queue struct {
-	Conn redis.Conn
-	Name string
-	Pool *redis.Pool
+	Conn      redis.Conn
+	Name      string
+	Namespace string
+	Pool      *redis.Pool
+	// Lease is how long a reserved job stays in flight before being delivered
+	// again, 30 seconds by default.
+	Lease time.Duration
+	// MaxAttempts is how many times a job is delivered before being moved to
+	// the dead jobs, 3 by default.
+	MaxAttempts int
+	// RetryDelay is the delay before the first retry of a failed job, doubled
+	// at every attempt, 1 second by default.
+	RetryDelay time.Duration
+	// ResultTTL is how long the results of completed jobs are kept for Wait,
+	// they are not stored when it is 0.
+	ResultTTL time.Duration
+	// PriorityAging raises the priority of due jobs by 1 for every
+	// PriorityAging they wait, so that jobs of low priority don't starve. Due
+	// jobs aren't aged when it is 0.
+	PriorityAging time.Duration
+	// MaxSize bounds the jobs waiting in the queue, due, scheduled or parked
+	// on their dependencies, Overflow telling what Push does beyond it. The
+	// queue is unbounded when it is 0.
+	MaxSize  int
+	Overflow Overflow
+
+	audit           *Audit
+	observers       []Observer
+	statusRetention *time.Duration
+	tracerProvider  trace.TracerProvider
 }
 
 type LoopOptions struct {
 	Size  int
 	Sleep time.Duration
+	// CancelCheck is how often Work checks whether the running job was
+	// canceled, 1 second by default.
+	CancelCheck time.Duration
+	// Concurrency is how many jobs Work runs at once, 1 by default. Jobs only
+	// run concurrently for queues with a Pool.
+	Concurrency int
+	// OnError is called by Work with the errors reserving and acknowledging
+	// jobs, Work keeps going.
+	OnError func(error)
 }
 
 type Option func(*Queue)
@@ -24,6 +63,17 @@ type Option func(*Queue)
 func WithConn(c redis.Conn) Option  { return func(q *Queue) { q.Conn = c } }
 func WithPool(p *redis.Pool) Option { return func(q *Queue) { q.Pool = p } }
 
+// WithNamespace prefixes every key of the queue with `ns:`
+func WithNamespace(ns string) Option { return func(q *Queue) { q.Namespace = ns } }
+
+func WithLease(d time.Duration) Option      { return func(q *Queue) { q.Lease = d } }
+func WithMaxAttempts(n int) Option          { return func(q *Queue) { q.MaxAttempts = n } }
+func WithRetryDelay(d time.Duration) Option { return func(q *Queue) { q.RetryDelay = d } }
+func WithResultTTL(d time.Duration) Option  { return func(q *Queue) { q.ResultTTL = d } }
+
+// WithPriorityAging raises the priority of due jobs by 1 for every d they wait.
+func WithPriorityAging(d time.Duration) Option { return func(q *Queue) { q.PriorityAging = d } }
+
 func (q *Queue) conn() (redis.Conn, bool) {
 	if q.Conn == nil && q.Pool == nil {
 		panic("no connection defined")
@@ -34,7 +84,30 @@ func (q *Queue) conn() (redis.Conn, bool) {
 	return q.Conn, false
 }
 
-// Loop over the queue
+func (q *Queue) prefix() string {
+	if q.Namespace == "" {
+		return ""
+	}
+	return q.Namespace + ":"
+}
+
+// key is the redis key of the queue, every other key used by the queue is
+// derived from it by adding a suffix.
+func (q *Queue) key() string { return q.prefix() + q.Name }
+
+// registry is the set of the queue names of the namespace.
+func (q *Queue) registry() string { return q.prefix() + "airq:queues" }
+
+// Sibling returns the queue called name sharing the connection, namespace and
+// options of q.
+func (q *Queue) Sibling(name string) *Queue {
+	s := *q
+	s.Name = name
+	return &s
+}
+
+// Loop over the queue. A panic of cb is recovered and reported as the error of
+// the EventProcess of the popped jobs, the loop then goes on.
 func (q *Queue) Loop(cb func([]string, error), opts *LoopOptions) {
 	if opts == nil {
 		opts = new(LoopOptions)
@@ -48,24 +121,47 @@ func (q *Queue) Loop(cb func([]string, error), opts *LoopOptions) {
 	for {
 		jobs, err := q.PopJobs(opts.Size)
 		if err != nil || len(jobs) > 0 {
-			cb(jobs, err)
+			start := time.Now()
+			perr := loopCall(cb, jobs, err)
+			if len(jobs) > 0 || perr != nil {
+				q.notify(EventProcess, nil, time.Since(start), perr)
+			}
 			continue
 		}
 		time.Sleep(opts.Sleep)
 	}
 }
 
+// loopCall calls cb with the popped jobs, it returns the panic of cb as an
+// error.
+func loopCall(cb func([]string, error), jobs []string, err error) (perr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			perr = fmt.Errorf("panic processing jobs %v: %v\n%s", jobs, r, debug.Stack())
+		}
+	}()
+	cb(jobs, err)
+	return nil
+}
+
 // New defines a new Queue
-func New(name string, opt Option) *Queue {
+func New(name string, opts ...Option) *Queue {
 	q := &Queue{Name: name}
-	opt(q)
+	for _, opt := range opts {
+		opt(q)
+	}
 	return q
 }
 
 // Push schedule a job at some point in the future, or some point in the past.
-// Scheduling a job far in the past is the same as giving it a high priority,
-// as jobs are popped in order of due date.
-func (q *Queue) Push(jobs ...*Job) (ids []string, err error) {
+// Due jobs are popped by Priority, then in order of due date, the tenants of
+// the jobs taking turns. Push fails with ErrTenantFull when a tenant would
+// exceed its maximum size.
+func (q *Queue) Push(jobs ...*Job) ([]string, error) {
+	return q.PushContext(context.Background(), jobs...)
+}
+
+func (q *Queue) push(jobs []*Job, b *batch) (ids []string, err error) {
 	if len(jobs) == 0 {
 		return []string{}, fmt.Errorf("no jobs provided")
 	}
@@ -73,16 +169,37 @@ func (q *Queue) Push(jobs ...*Job) (ids []string, err error) {
 	if managed {
 		defer c.Close()
 	}
-	keysAndArgs := redis.Args{q.Name}
+	keysAndArgs := append(redis.Args{q.key(), q.registry()}, q.trackArgs()...).Add(q.Name, q.MaxSize, q.Overflow)
+	keysAndArgs = append(keysAndArgs, b.args()...)
 	for _, j := range jobs {
 		keysAndArgs = keysAndArgs.AddFlat(j.String())
-		ids = append(ids, j.ID)
+		if err := j.checkFollowUps(); err != nil {
+			return nil, err
+		}
+	}
+	res, err := redis.Strings(pushScript.Do(c, keysAndArgs...))
+	if err != nil {
+		return nil, err
+	}
+	switch res[0] {
+	case "full":
+		return nil, fmt.Errorf("%w: %s", ErrQueueFull, q.Name)
+	case "tenant":
+		return nil, fmt.Errorf("%w: %s", ErrTenantFull, res[1])
+	case "batch":
+		return nil, fmt.Errorf("%w: %s", ErrInBatch, res[1])
 	}
-	ok, err := redis.Int(pushScript.Do(c, keysAndArgs...))
-	if err == nil && ok != 1 {
-		err = fmt.Errorf("can't add all jobs %v to queue %s", jobs, q.Name)
+	dropped := res[1:]
+	if q.Overflow == OverflowDropNewest && len(dropped) > 0 {
+		jobs = keepJobs(jobs, dropped)
+	} else if len(dropped) > 0 {
+		q.notify(EventRemove, idJobs(dropped...), 0, nil)
 	}
-	return ids, err
+	for _, j := range jobs {
+		ids = append(ids, j.ID)
+	}
+	q.notify(EventPush, jobs, 0, nil)
+	return ids, nil
 }
 
 // Pending returns the count of jobs pending, including scheduled jobs that are not due yet.
@@ -91,11 +208,12 @@ func (q *Queue) Pending() (int64, error) {
 	if managed {
 		defer c.Close()
 	}
-	return redis.Int64(c.Do("ZCARD", q.Name))
+	return redis.Int64(c.Do("ZCARD", q.key()))
 }
 
 // Pop removes and returns a single job from the queue. Safe for concurrent use
-// (multiple goroutines must use their own Queue objects and redis connections)
+// (multiple goroutines must use their own Queue objects and redis connections).
+// A popped job counts as succeeded, see PopJobs.
 func (q *Queue) Pop() (string, error) {
 	jobs, err := q.PopJobs(1)
 	if err != nil {
@@ -108,7 +226,11 @@ func (q *Queue) Pop() (string, error) {
 }
 
 // PopJobs returns multiple jobs from the queue. Safe for concurrent use
-// (multiple goroutines must use their own Queue objects and redis connections)
+// (multiple goroutines must use their own Queue objects and redis connections).
+// Popped jobs leave the queue and count as succeeded as they are popped: their
+// Then job is pushed, they succeed in their batch and the jobs depending on
+// them are queued, even if the consumer then fails. Reserve jobs to have them
+// followed once acknowledged instead.
 func (q *Queue) PopJobs(limit int) (res []string, err error) {
 	if limit == 0 {
 		return []string{}, fmt.Errorf("limit 0")
@@ -117,15 +239,25 @@ func (q *Queue) PopJobs(limit int) (res []string, err error) {
 	if managed {
 		defer c.Close()
 	}
-	redisRes, err := redis.Strings(popJobsScript.Do(
-		c, q.Name, time.Now().UnixNano(), limit,
+	keysAndArgs := append(redis.Args{q.key()}, q.trackArgs()...)
+	redisRes, err := redis.Values(popJobsScript.Do(
+		c, keysAndArgs.Add(time.Now().UnixNano(), limit, q.PriorityAging.Nanoseconds())...,
 	))
 	if err != nil {
 		return nil, err
 	}
-	for _, r := range redisRes {
-		res = append(res, uncompress(r))
+	var jobs []*Job
+	for len(redisRes) > 0 {
+		var id, when, value string
+		if redisRes, err = redis.Scan(redisRes, &id, &when, &value); err != nil {
+			return nil, err
+		}
+		j := decode(value)
+		j.ID, j.When = id, parseScore(when)
+		jobs = append(jobs, j)
+		res = append(res, jobs[len(jobs)-1].Content)
 	}
+	q.notify(EventPop, jobs, 0, nil)
 	return res, nil
 }
 
@@ -138,9 +270,13 @@ func (q *Queue) Remove(ids ...string) error {
 	if managed {
 		defer c.Close()
 	}
-	ok, err := redis.Int(removeScript.Do(c, redis.Args{q.Name}.AddFlat(ids)...))
-	if err == nil && ok != 1 {
+	keysAndArgs := append(redis.Args{q.key()}, q.trackArgs()...)
+	ok, err := redis.Int(removeScript.Do(c, keysAndArgs.AddFlat(ids)...))
+	if err == nil && ok != len(ids) {
 		err = fmt.Errorf("can't delete all jobs %v in queue %s", ids, q.Name)
 	}
+	if err == nil {
+		q.notify(EventRemove, idJobs(ids...), 0, nil)
+	}
 	return err
 }
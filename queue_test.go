@@ -4,13 +4,14 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
 )
 
-func setup(t *testing.T) (*Queue, func()) {
+func setup(t *testing.T, opts ...Option) (*Queue, func()) {
 	t.Parallel()
 	name := randomName()
 	c, err := redis.Dial("tcp", "127.0.0.1:6379")
@@ -18,10 +19,10 @@ func setup(t *testing.T) (*Queue, func()) {
 		t.Error(err)
 		t.FailNow()
 	}
-	q := New(name, WithConn(c))
+	q := New(name, append([]Option{WithConn(c)}, opts...)...)
 	teardown := func() {
-		q.Conn.Send("DEL", q.Name)
-		q.Conn.Send("DEL", q.Name+":values")
+		q.Delete()
+		q.Conn.Do("DEL", q.key()+":events")
 		q.Conn.Close()
 	}
 	return q, teardown
@@ -235,3 +236,118 @@ func TestRemove(t *testing.T) {
 		t.Error("Expected to having jobs off the queue:", expected, " but I got this:", jobs)
 	}
 }
+
+func TestNamespace(t *testing.T) {
+	ns := randomName()
+	q, teardown := setup(t, WithNamespace(ns))
+	defer teardown()
+
+	addJobs(t, q, []Job{Job{Content: "namespaced"}})
+
+	if n, _ := redis.Int(q.Conn.Do("ZCARD", ns+":"+q.Name)); n != 1 {
+		t.Error("Expected the job to be stored under the namespace, got", n)
+	}
+	if n, _ := redis.Int(q.Conn.Do("EXISTS", q.Name)); n != 0 {
+		t.Error("Expected no key outside of the namespace")
+	}
+
+	other := New(q.Name, WithConn(q.Conn))
+	if job, _ := other.Pop(); job != "" {
+		t.Error("Expected queues in different namespaces to be isolated, got", job)
+	}
+
+	queues, err := q.ListQueues()
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	expected := []QueueInfo{{Name: q.Name, Size: 1}}
+	if !reflect.DeepEqual(queues, expected) {
+		t.Error("Expected to list", expected, "but got", queues)
+	}
+}
+
+// TestBacklog checks that a job is handed out behind a backlog of due jobs
+// which can't go first.
+func TestBacklog(t *testing.T) {
+	old := time.Now().Add(-time.Hour)
+	for _, tt := range []struct {
+		name    string
+		backlog Job
+		limit   string
+		jobs    []*Job
+		expect  []string // contents of the successive reservations
+	}{
+		{
+			name:    "priority",
+			backlog: Job{Content: "old", When: old},
+			jobs:    []*Job{&Job{Content: "urgent", Priority: 1, When: time.Now().Add(-time.Second)}},
+			expect:  []string{"urgent", "old"},
+		},
+		{
+			name:    "group",
+			backlog: Job{Content: "a", GroupKey: "a", When: old},
+			jobs:    []*Job{&Job{Content: "b", GroupKey: "b", When: time.Now().Add(-time.Second)}},
+			expect:  []string{"a", "b"},
+		},
+		{
+			name:    "limit",
+			backlog: Job{Content: "export", Type: "export", When: old},
+			limit:   "type",
+			jobs:    []*Job{&Job{Content: "email", Type: "email", When: time.Now().Add(-time.Second)}},
+			expect:  []string{"export", "email"},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			q, teardown := setup(t)
+			defer teardown()
+
+			if tt.limit != "" {
+				q.SetConcurrencyLimit(1, tt.limit)
+			}
+			var jobs []*Job
+			for i := 0; i < 20; i++ {
+				j := tt.backlog
+				j.Unique = true
+				jobs = append(jobs, &j)
+			}
+			q.Push(append(jobs, tt.jobs...)...)
+			for _, content := range tt.expect {
+				if jobs, _ := q.Reserve(1); len(jobs) != 1 || jobs[0].Content != content {
+					t.Error("Expected", content, "got", jobs)
+				}
+			}
+		})
+	}
+}
+
+func TestLoopPanic(t *testing.T) {
+	processed := make(chan error, 2)
+	q, teardown := setup(t, WithObserver(hook{EventProcess, func(e *Event) { processed <- e.Err }}))
+	defer teardown()
+
+	now := time.Now()
+	addJobs(t, q, []Job{Job{Content: "a", When: now.Add(-time.Second)}, Job{Content: "b", When: now}})
+	done := make(chan string)
+	go q.Loop(func(jobs []string, err error) {
+		if len(jobs) == 1 && jobs[0] == "a" {
+			panic("boom")
+		}
+		done <- jobs[0]
+		select {} // the loop never returns
+	}, &LoopOptions{Size: 1, Sleep: time.Millisecond})
+
+	select {
+	case job := <-done:
+		if job != "b" {
+			t.Error("Expected the loop to go on with b, got", job)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the loop to go on after a panic")
+		t.FailNow()
+	}
+	if err := <-processed; err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Error("Expected the panic to be reported, got", err)
+	}
+}
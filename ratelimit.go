@@ -0,0 +1,40 @@
+package airq
+
+import (
+	"math"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// SetRateLimit limits the consumption of the queue by all its consumers
+// together to rate jobs per second, in bursts of up to burst jobs, rate by
+// default. The limit applies at once to running consumers, a rate of 0 removes
+// it.
+func (q *Queue) SetRateLimit(rate float64, burst int) error {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	if rate <= 0 {
+		_, err := c.Do("DEL", q.key()+":ratelimit")
+		return err
+	}
+	if burst < 1 {
+		burst = int(math.Ceil(rate))
+	}
+	_, err := c.Do("HSET", q.key()+":ratelimit", "rate", rate, "burst", burst)
+	return err
+}
+
+// RateLimit returns the rate limit of the queue, a rate of 0 when it has none.
+func (q *Queue) RateLimit() (rate float64, burst int, err error) {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	res, err := redis.Values(c.Do("HMGET", q.key()+":ratelimit", "rate", "burst"))
+	if err == nil && res[0] != nil {
+		_, err = redis.Scan(res, &rate, &burst)
+	}
+	return rate, burst, err
+}
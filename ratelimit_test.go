@@ -0,0 +1,39 @@
+package airq
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	for i := 0; i < 10; i++ {
+		q.Push(&Job{Content: randomName()})
+	}
+	if err := q.SetRateLimit(100, 3); err != nil {
+		t.Error(err)
+	}
+	if rate, burst, _ := q.RateLimit(); rate != 100 || burst != 3 {
+		t.Error("Expected a rate limit of 100/s in bursts of 3, got", rate, burst)
+	}
+	if jobs, _ := q.PopJobs(10); len(jobs) != 3 {
+		t.Error("Expected a burst of 3 jobs, got", len(jobs))
+	}
+	if jobs, _ := q.Reserve(10); len(jobs) != 0 {
+		t.Error("Expected no job once the bucket is empty, got", len(jobs))
+	}
+	time.Sleep(25 * time.Millisecond)
+	if jobs, _ := q.Reserve(10); len(jobs) < 1 || len(jobs) > 3 {
+		t.Error("Expected the bucket to refill at the rate, got", len(jobs))
+	}
+
+	q.SetRateLimit(0, 0)
+	if rate, _, _ := q.RateLimit(); rate != 0 {
+		t.Error("Expected the rate limit to be removed, got", rate)
+	}
+	if jobs, _ := q.Reserve(10); len(jobs) == 0 {
+		t.Error("Expected the remaining jobs without rate limit")
+	}
+}
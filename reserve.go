@@ -0,0 +1,174 @@
+package airq
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+func (q *Queue) lease() time.Duration {
+	if q.Lease <= 0 {
+		return 30 * time.Second
+	}
+	return q.Lease
+}
+
+func (q *Queue) maxAttempts() int {
+	if q.MaxAttempts <= 0 {
+		return 3
+	}
+	return q.MaxAttempts
+}
+
+func (q *Queue) retryDelay() time.Duration {
+	if q.RetryDelay <= 0 {
+		return time.Second
+	}
+	return q.RetryDelay
+}
+
+// Reserve returns due jobs like PopJobs but keeps them in flight until they are
+// acknowledged with Ack or Fail. A job which is not acknowledged before the
+// lease of the queue ends is delivered again, or moved to the dead jobs once
+// delivered MaxAttempts times.
+func (q *Queue) Reserve(limit int) ([]*Job, error) {
+	if limit == 0 {
+		return []*Job{}, fmt.Errorf("limit 0")
+	}
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	keysAndArgs := append(redis.Args{q.key()}, q.trackArgs()...)
+	reply, err := redis.Values(reserveScript.Do(c, keysAndArgs.Add(time.Now().UnixNano(), limit).Add(q.reserveArgs()...)...))
+	if err != nil {
+		return nil, err
+	}
+	return q.reserved(reply)
+}
+
+// reserveArgs are the arguments of the reservation of jobs of the queue
+// following the time and limit.
+func (q *Queue) reserveArgs() redis.Args {
+	return redis.Args{q.lease().Nanoseconds(), q.maxAttempts(), q.ResultTTL.Milliseconds(), q.PriorityAging.Nanoseconds()}
+}
+
+// reserved decodes the reply of the reservation of jobs of the queue, the jobs
+// retried and dead after their lease expired and the reserved jobs.
+func (q *Queue) reserved(reply []interface{}) ([]*Job, error) {
+	var retried, dead []string
+	var res []interface{}
+	if _, err := redis.Scan(reply, &retried, &dead, &res); err != nil {
+		return nil, err
+	}
+	expired := fmt.Errorf("lease expired")
+	q.notify(EventRetry, idJobs(retried...), 0, expired)
+	q.notify(EventDead, idJobs(dead...), 0, expired)
+	jobs := []*Job{}
+	for len(res) > 0 {
+		var id, when, value string
+		var attempts int
+		var err error
+		if res, err = redis.Scan(res, &id, &when, &attempts, &value); err != nil {
+			return nil, err
+		}
+		j := decode(value)
+		j.Attempts, j.ID, j.When, j.queue = attempts, id, parseScore(when), q
+		jobs = append(jobs, j)
+	}
+	q.notify(EventPop, jobs, 0, nil)
+	return jobs, nil
+}
+
+// Ack acknowledges reserved jobs as done and removes them from the queue, it
+// fails for jobs which are not in flight. A job pushed again while in flight
+// stays queued.
+func (q *Queue) Ack(ids ...string) error {
+	return q.ack(ids, make([]Result, len(ids)))
+}
+
+// ack acknowledges jobs with their results.
+func (q *Queue) ack(ids []string, results []Result) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("no id provided")
+	}
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	keysAndArgs := append(redis.Args{q.key()}, q.trackArgs()...).Add(q.ResultTTL.Milliseconds())
+	for i, id := range ids {
+		keysAndArgs = keysAndArgs.Add(id, results[i].Value, results[i].Err)
+	}
+	n, err := redis.Int(ackScript.Do(c, keysAndArgs...))
+	if err == nil && n != len(ids) {
+		err = fmt.Errorf("can't ack all jobs %v in queue %s", ids, q.Name)
+	}
+	if err == nil {
+		q.notify(EventAck, idJobs(ids...), 0, nil)
+	}
+	return err
+}
+
+// Fail acknowledges a reserved job as failed. It is scheduled again after a
+// delay growing with its attempts, or moved to the dead jobs once delivered
+// MaxAttempts times. A job canceled while running is dropped instead.
+func (q *Queue) Fail(id string, cause error) error {
+	return q.fail(id, cause, q.maxAttempts())
+}
+
+// Bury moves a reserved job to the dead jobs without retrying it.
+func (q *Queue) Bury(id string, cause error) error {
+	return q.fail(id, cause, 0)
+}
+
+func (q *Queue) fail(id string, cause error, maxAttempts int) error {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	msg := ""
+	if cause != nil {
+		msg = cause.Error()
+	}
+	keysAndArgs := append(redis.Args{q.key()}, q.trackArgs()...)
+	res, err := redis.Int(failScript.Do(c, keysAndArgs.Add(
+		time.Now().UnixNano(), maxAttempts, q.retryDelay().Nanoseconds(), id, msg, q.ResultTTL.Milliseconds(),
+	)...))
+	if err == nil && res < 0 {
+		err = fmt.Errorf("job %s is not in flight in queue %s", id, q.Name)
+	}
+	if err != nil {
+		return err
+	}
+	q.notify(EventFail, idJobs(id), 0, cause)
+	switch res {
+	case 1:
+		q.notify(EventRetry, idJobs(id), 0, cause)
+	case 0:
+		q.notify(EventDead, idJobs(id), 0, cause)
+	}
+	return nil
+}
+
+// release puts a reserved job back in the queue without counting its attempt.
+func (q *Queue) release(id string) error {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	keysAndArgs := append(redis.Args{q.key()}, q.trackArgs()...)
+	ok, err := redis.Bool(releaseScript.Do(c, keysAndArgs.Add(id, time.Now().UnixNano())...))
+	if err == nil && !ok {
+		err = fmt.Errorf("job %s is not in flight in queue %s", id, q.Name)
+	}
+	return err
+}
+
+// parseScore reads a zset score holding a time in nanoseconds.
+func parseScore(s string) time.Time {
+	f, _ := strconv.ParseFloat(s, 64)
+	return time.Unix(0, int64(f))
+}
@@ -0,0 +1,111 @@
+package airq
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReserveAck(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	addJobs(t, q, []Job{Job{Content: "reserved", ID: "01"}})
+
+	jobs, err := q.Reserve(10)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if len(jobs) != 1 || jobs[0].ID != "01" || jobs[0].Content != "reserved" || jobs[0].Attempts != 1 {
+		t.Error("Expected to reserve the job, got", jobs)
+		t.FailNow()
+	}
+	if jobs, _ = q.Reserve(10); len(jobs) != 0 {
+		t.Error("Expected a reserved job not to be delivered twice, got", jobs)
+	}
+
+	if err := q.Ack("01"); err != nil {
+		t.Error(err)
+	}
+	if stats, _ := q.Stats(); stats.InFlight != 0 {
+		t.Error("Expected no job in flight after ack, was", stats.InFlight)
+	}
+	if err := q.Ack("01"); err == nil {
+		t.Error("Expected an error acking an unknown job")
+	}
+}
+
+func TestAckWaiting(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	addJobs(t, q, []Job{Job{Content: "waiting", ID: "01"}})
+	if err := q.Ack("01"); err == nil {
+		t.Error("Expected an error acking a job which wasn't reserved")
+	}
+	if n, _ := q.Pending(); n != 1 {
+		t.Error("Expected the job not to be removed, got", n)
+	}
+	q.Reserve(1)
+	addJobs(t, q, []Job{Job{Content: "waiting", ID: "01"}})
+	if err := q.Ack("01"); err != nil {
+		t.Error(err)
+	}
+	if jobs, _ := q.Reserve(1); len(jobs) != 1 || jobs[0].Content != "waiting" {
+		t.Error("Expected the job pushed again to stay queued, got", jobs)
+	}
+}
+
+func TestFail(t *testing.T) {
+	q, teardown := setup(t, WithMaxAttempts(2), WithRetryDelay(10*time.Millisecond))
+	defer teardown()
+
+	addJobs(t, q, []Job{Job{Content: "failing", ID: "01"}})
+
+	q.Reserve(1)
+	if err := q.Fail("01", errors.New("boom")); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if stats, _ := q.Stats(); stats.Scheduled != 1 {
+		t.Error("Expected the failed job to be scheduled for retry, was", stats.Scheduled)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	jobs, _ := q.Reserve(1)
+	if len(jobs) != 1 || jobs[0].Attempts != 2 {
+		t.Error("Expected the job to be retried, got", jobs)
+		t.FailNow()
+	}
+	q.Fail("01", errors.New("boom"))
+	if stats, _ := q.Stats(); stats.Dead != 1 || stats.Scheduled != 0 {
+		t.Error("Expected the job to be dead, was", stats)
+	}
+
+	if err := q.Fail("01", nil); err == nil {
+		t.Error("Expected an error failing a job not in flight")
+	}
+}
+
+func TestLeaseExpiry(t *testing.T) {
+	q, teardown := setup(t, WithLease(10*time.Millisecond), WithMaxAttempts(2))
+	defer teardown()
+
+	addJobs(t, q, []Job{Job{Content: "slow", ID: "01"}})
+
+	q.Reserve(1)
+	time.Sleep(20 * time.Millisecond)
+	jobs, _ := q.Reserve(1)
+	if len(jobs) != 1 || jobs[0].Attempts != 2 {
+		t.Error("Expected the job to be delivered again, got", jobs)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if jobs, _ = q.Reserve(1); len(jobs) != 0 {
+		t.Error("Expected the job to be out of attempts, got", jobs)
+	}
+	if stats, _ := q.Stats(); stats.Dead != 1 {
+		t.Error("Expected the job to be dead, was", stats.Dead)
+	}
+}
@@ -0,0 +1,76 @@
+package airq
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/vmihailenco/msgpack"
+)
+
+// waitPoll is how often Wait checks whether a job completed.
+const waitPoll = 100 * time.Millisecond
+
+// ErrNoResults is returned by Wait when the queue doesn't keep the results of
+// its jobs, see WithResultTTL.
+var ErrNoResults = errors.New("results not kept")
+
+// Result is the outcome of a completed job, its Err is set when the job died
+// or was canceled.
+type Result struct {
+	Value string `msgpack:"value"`
+	Err   string `msgpack:"error"`
+}
+
+// SetResult sets the result of a job, stored when its handler succeeds.
+func (j *Job) SetResult(value string) { j.result = value }
+
+// ResultFunc adapts a function returning the result of a job to a Handler.
+type ResultFunc func(ctx context.Context, j *Job) (string, error)
+
+// Process calls f(ctx, j) and sets the result of j.
+func (f ResultFunc) Process(ctx context.Context, j *Job) error {
+	value, err := f(ctx, j)
+	if err == nil {
+		j.SetResult(value)
+	}
+	return err
+}
+
+// Wait blocks until the job id completes and returns its result, the queue
+// must keep results with a ResultTTL or Wait fails with ErrNoResults.
+func (q *Queue) Wait(ctx context.Context, id string) (*Result, error) {
+	if q.ResultTTL <= 0 {
+		return nil, ErrNoResults
+	}
+	ticker := time.NewTicker(waitPoll)
+	defer ticker.Stop()
+	for {
+		res, err := q.result(id)
+		if res != nil || err != nil {
+			return res, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (q *Queue) result(id string) (*Result, error) {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	b, err := redis.Bytes(c.Do("GET", q.key()+":result:"+id))
+	if err == redis.ErrNil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := new(Result)
+	return res, msgpack.Unmarshal(b, res)
+}
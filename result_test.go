@@ -0,0 +1,49 @@
+package airq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWait(t *testing.T) {
+	q, teardown := setup(t, WithResultTTL(time.Minute), WithMaxAttempts(1))
+	defer teardown()
+
+	ids, _ := q.Push(&Job{Content: "ok"}, &Job{Content: "ko"})
+	h := ResultFunc(func(ctx context.Context, j *Job) (string, error) {
+		if j.Content == "ko" {
+			return "", errors.New("boom")
+		}
+		return "done " + j.Content, nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	q.Work(ctx, h, &LoopOptions{Sleep: 10 * time.Millisecond})
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if res, err := q.Wait(ctx, ids[0]); err != nil || res.Value != "done ok" || res.Err != "" {
+		t.Error("Expected the result of the job, got", res, err)
+	}
+	if res, err := q.Wait(ctx, ids[1]); err != nil || res.Err != "boom" {
+		t.Error("Expected the error of the dead job, got", res, err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Wait(ctx, "unknown"); err != context.DeadlineExceeded {
+		t.Error("Expected Wait to block until its context is done, got", err)
+	}
+}
+
+func TestWaitWithoutResults(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	ids, _ := q.Push(&Job{Content: "ok"})
+	if _, err := q.Wait(context.Background(), ids[0]); err != ErrNoResults {
+		t.Error("Expected ErrNoResults, got", err)
+	}
+}
@@ -2,30 +2,1150 @@ package airq
 
 import "github.com/gomodule/redigo/redis"
 
-var popJobsScript = redis.NewScript(1, `
+// auditLua is the prelude of the scripts recording their operations in the
+// audit log, they take the audit arguments of the queue as ARGV[1..3].
+const auditLua = `
+-- Q is the queue the prelude functions work on
+local Q = KEYS[1]
+local audit_by, audit_maxlen, audit_minid = ARGV[1], tonumber(ARGV[2]), ARGV[3]
+local function audit(op, id)
+	if audit_by ~= "" then
+		redis.call("xadd", Q .. ":events", "*", "op", op, "id", id, "by", audit_by)
+	end
+end
+local function audit_trim()
+	if audit_by == "" then return end
+	if audit_maxlen > 0 then
+		redis.call("xtrim", Q .. ":events", "MAXLEN", "~", audit_maxlen)
+	end
+	if audit_minid ~= "0" then
+		redis.call("xtrim", Q .. ":events", "MINID", "~", audit_minid)
+	end
+end
+`
+
+// statusLua is the prelude of the scripts recording the status of the jobs,
+// they take the status retention of the queue in milliseconds as ARGV[4], -1
+// when statuses aren't tracked.
+const statusLua = `
+local status_retention = tonumber(ARGV[4])
+local function status_time()
+	local t = redis.call("time")
+	return t[1] .. string.format("%06d", t[2]) .. "000", t[1] * 1000 + math.floor(t[2] / 1000)
+end
+local function status(id, state, when, done)
+	if status_retention < 0 then return end
+	local key = Q .. ":status"
+	local value = redis.call("hget", key, id)
+	local rec = value and cmsgpack.unpack(value) or {times = {}}
+	local now, now_ms = status_time()
+	-- a waiting job became due at its due date
+	local waiting = rec.state == "scheduled" or rec.state == "failed" or rec.state == "expired"
+	if waiting and tonumber(rec.when) <= tonumber(now) then rec.times.due = rec.when end
+	rec.state = state
+	rec.times[state] = now
+	if when then rec.when = string.format("%.0f", when) end
+	redis.call("hset", key, id, cmsgpack.pack(rec))
+	if done then
+		redis.call("zadd", key .. ":done", now_ms, id)
+	else
+		redis.call("zrem", key .. ":done", id)
+	end
+end
+local function status_forget(id)
+	redis.call("hdel", Q .. ":status", id)
+	redis.call("zrem", Q .. ":status:done", id)
+end
+local function status_trim()
+	if status_retention <= 0 then return end
+	local _, now_ms = status_time()
+	local done = Q .. ":status:done"
+	local ids = redis.call("zrangebyscore", done, "-inf", now_ms - status_retention, "LIMIT", 0, 1000)
+	if #ids == 0 then return end
+	redis.call("hdel", Q .. ":status", unpack(ids))
+	redis.call("zrem", done, unpack(ids))
+end
+`
+
+// trackLua is the prelude of the scripts recording their operations, they take
+// the tracking arguments of the queue as ARGV[1..4].
+const trackLua = auditLua + statusLua
+
+// rateLua is the prelude of the scripts popping jobs under the rate limit of
+// the queue, a token bucket refilled at rate tokens per second up to burst.
+const rateLua = `
+local rate_tokens, rate_now
+-- rate_allow returns how many of limit jobs can be popped
+local function rate_allow(limit)
+	local conf = redis.call("hmget", Q .. ":ratelimit", "rate", "burst", "tokens", "ts")
+	local rate, burst = tonumber(conf[1]), tonumber(conf[2])
+	if not rate then return limit end
+	local t = redis.call("time")
+	rate_now = t[1] * 1000 + math.floor(t[2] / 1000)
+	local tokens, ts = tonumber(conf[3]) or burst, tonumber(conf[4]) or rate_now
+	rate_tokens = math.min(burst, tokens + (rate_now - ts) * rate / 1000)
+	return math.min(limit, math.floor(rate_tokens))
+end
+-- rate_take takes the tokens of n popped jobs
+local function rate_take(n)
+	if not rate_tokens then return end
+	redis.call("hset", Q .. ":ratelimit", "tokens", rate_tokens - n, "ts", rate_now)
+end
+`
+
+// limitLua enforces the concurrency limits of the queue: a limit on job
+// attributes, such as "type,tenant", caps the reserved jobs sharing the same
+// values of these attributes. The attributes are type, group, tenant or
+// metadata keys.
+const limitLua = `
+local limit_defs, limit_pending, limit_counters = nil, {}, {}
+-- limit_allows tells whether the job id can run, counting the jobs already
+-- allowed by the script, or returns false with the counter and the limit
+-- holding it back
+local function limit_allows(id)
+	if limit_defs == nil then limit_defs = redis.call("hgetall", Q .. ":limits") end
+	if #limit_defs == 0 then return true end
+	local ok, job = pcall(cmsgpack.unpack, redis.call("hget", Q .. ":values", id))
+	if not ok or type(job) ~= "table" then return true end
+	local counters = {}
+	for i=1, #limit_defs, 2 do
+		local values = {}
+		for attr in string.gmatch(limit_defs[i], "[^,]+") do
+			local v
+			if attr == "type" then
+				v = job.type
+			elseif attr == "group" then
+				v = job.group
+			elseif attr == "tenant" and job.tenant then
+				v = job.tenant
+			elseif type(job.meta) == "table" then
+				v = job.meta[attr]
+			end
+			if v == nil or v == "" then values = nil break end
+			table.insert(values, v)
+		end
+		if values then
+			local counter = limit_defs[i] .. "=" .. table.concat(values, ",")
+			local running = tonumber(redis.call("hget", Q .. ":running", counter) or 0) + (limit_pending[counter] or 0)
+			if running >= tonumber(limit_defs[i+1]) then return false, counter, limit_defs[i] end
+			table.insert(counters, counter)
+		end
+	end
+	for _, counter in ipairs(counters) do limit_pending[counter] = (limit_pending[counter] or 0) + 1 end
+	limit_counters[id] = counters
+	return true
+end
+-- limit_take counts the reserved job id as running
+local function limit_take(id)
+	local counters = limit_counters[id]
+	if not counters or #counters == 0 then return end
+	for _, counter in ipairs(counters) do redis.call("hincrby", Q .. ":running", counter, 1) end
+	redis.call("hset", Q .. ":running:jobs", id, cmsgpack.pack(counters))
+end
+-- limit_release stops counting the job id as running
+local function limit_release(id)
+	local running = Q .. ":running"
+	local value = redis.call("hget", running .. ":jobs", id)
+	if not value then return end
+	for _, counter in ipairs(cmsgpack.unpack(value)) do
+		if redis.call("hincrby", running, counter, -1) <= 0 then
+			redis.call("hdel", running, counter)
+		end
+	end
+	redis.call("hdel", running .. ":jobs", id)
+end
+`
+
+// tenantLua indexes the waiting jobs of each tenant of the queue, the tenants
+// of the jobs are kept until they leave the queue.
+const tenantLua = `
+-- tenant_wait indexes the waiting job id under its tenant
+local function tenant_wait(id, when)
+	local tenant = redis.call("hget", Q .. ":tenants", id)
+	if not tenant then return end
+	redis.call("zadd", Q .. ":tenant:" .. tenant, when, id)
+	redis.call("sadd", Q .. ":tenants:waiting", tenant)
+end
+-- tenant_unwait removes the job id from the waiting jobs of its tenant
+local function tenant_unwait(id)
+	local tenant = redis.call("hget", Q .. ":tenants", id)
+	if not tenant then return end
+	local key = Q .. ":tenant:" .. tenant
+	redis.call("zrem", key, id)
+	if redis.call("exists", key) == 0 then redis.call("srem", Q .. ":tenants:waiting", tenant) end
+end
+-- tenant_forget drops the tenant of a job leaving the queue
+local function tenant_forget(id)
+	tenant_unwait(id)
+	redis.call("hdel", Q .. ":tenants", id)
+end
+`
+
+// groupLua is the prelude of the scripts handing out jobs by priority, one at
+// a time per group, under the concurrency limits and fairly between tenants.
+// A reserved job holds its group until it is acknowledged, dead or canceled,
+// retries included. The due jobs are indexed in lanes by tenant and priority,
+// ordered by due date, the scheduled jobs join their lane once due.
+const groupLua = limitLua + tenantLua + `
+-- due_now returns the time of redis in nanoseconds
+local function due_now()
+	local t = redis.call("time")
+	return t[1] * 1e9 + t[2] * 1000
+end
+-- lane_of returns the tenant of the job id, "" when it has none, its priority
+-- and the key of its lane
+local function lane_of(id)
+	local tenant = redis.call("hget", Q .. ":tenants", id) or ""
+	local priority = redis.call("hget", Q .. ":priorities", id) or "0"
+	return tenant, priority, Q .. ":lane:" .. priority .. ":" .. tenant
+end
+-- lane_add indexes the due job id in its lane
+local function lane_add(id, when)
+	local tenant, priority, key = lane_of(id)
+	redis.call("zadd", key, when, id)
+	redis.call("zadd", Q .. ":lanes:" .. tenant, priority, priority)
+	redis.call("zadd", Q .. ":lanes", 0, tenant)
+end
+-- lane_remove removes the job id from its lane, or from the jobs blocked by a
+-- concurrency limit
+local function lane_remove(id)
+	local counter = redis.call("hget", Q .. ":blocked", id)
+	if counter then
+		redis.call("hdel", Q .. ":blocked", id)
+		redis.call("zrem", Q .. ":blocked:" .. counter, id)
+		if redis.call("exists", Q .. ":blocked:" .. counter) == 0 then redis.call("hdel", Q .. ":blockers", counter) end
+		return
+	end
+	local tenant, priority, key = lane_of(id)
+	if redis.call("zrem", key, id) == 0 or redis.call("exists", key) == 1 then return end
+	local lanes = Q .. ":lanes:" .. tenant
+	redis.call("zrem", lanes, priority)
+	if redis.call("exists", lanes) == 0 then redis.call("zrem", Q .. ":lanes", tenant) end
+end
+-- limit_block moves the due job id out of its lane while the counter of limit
+-- is at the limit
+local function limit_block(id, when, counter, limit)
+	lane_remove(id)
+	redis.call("zadd", Q .. ":blocked:" .. counter, when, id)
+	redis.call("hset", Q .. ":blocked", id, counter)
+	redis.call("hset", Q .. ":blockers", counter, limit)
+end
+-- limit_unblock moves the blocked jobs back to their lane, as many as the
+-- counters blocking them are under their limit
+local function limit_unblock()
+	local blockers = redis.call("hgetall", Q .. ":blockers")
+	for i=1, #blockers, 2 do
+		local counter, key = blockers[i], Q .. ":blocked:" .. blockers[i]
+		local max = tonumber(redis.call("hget", Q .. ":limits", blockers[i+1]) or 0)
+		-- every job is moved back once the limit is removed
+		local last = -1
+		if max > 0 then last = max - tonumber(redis.call("hget", Q .. ":running", counter) or 0) - 1 end
+		if max <= 0 or last >= 0 then
+			local ids = redis.call("zrange", key, 0, last, "WITHSCORES")
+			for j=1, #ids, 2 do
+				redis.call("zrem", key, ids[j])
+				redis.call("hdel", Q .. ":blocked", ids[j])
+				lane_add(ids[j], ids[j+1])
+			end
+			if redis.call("exists", key) == 0 then redis.call("hdel", Q .. ":blockers", counter) end
+		end
+	end
+end
+-- group_ready indexes the job of group which can run next in its lane once
+-- due: the job holding the group while it waits for a retry, or the first job
+-- of the group when it isn't held
+local function group_ready(group)
+	local key, heads = Q .. ":group:" .. group, Q .. ":groupheads"
+	local head = redis.call("hget", Q .. ":grouplocks", group)
+	if not head then
+		head = redis.call("zrange", key, 0, 0)[1]
+	elseif not redis.call("zscore", key, head) then
+		head = nil
+	end
+	local current = redis.call("hget", heads, group)
+	if current == head then return end
+	if current then
+		lane_remove(current)
+		redis.call("hdel", heads, group)
+	end
+	if head and not redis.call("zscore", Q .. ":scheduled", head) then
+		lane_add(head, redis.call("zscore", key, head))
+		redis.call("hset", heads, group, head)
+	end
+end
+-- wait queues the job id, due at when
+local function wait(id, when)
+	redis.call("zadd", Q, when, id)
+	tenant_wait(id, when)
+	local group = redis.call("hget", Q .. ":groups", id)
+	if group then redis.call("zadd", Q .. ":group:" .. group, when, id) end
+	if tonumber(when) > due_now() then
+		redis.call("zadd", Q .. ":scheduled", when, id)
+	elseif not group then
+		lane_add(id, when)
+	end
+	if group then group_ready(group) end
+end
+-- unwait removes the job id from the waiting jobs, it returns whether it was
+-- waiting
+local function unwait(id)
+	if redis.call("zrem", Q, id) == 0 then return false end
+	tenant_unwait(id)
+	redis.call("zrem", Q .. ":scheduled", id)
+	lane_remove(id)
+	local group = redis.call("hget", Q .. ":groups", id)
+	if group then
+		redis.call("zrem", Q .. ":group:" .. group, id)
+		if redis.call("hget", Q .. ":groupheads", group) == id then redis.call("hdel", Q .. ":groupheads", group) end
+		group_ready(group)
+	end
+	return true
+end
+-- promote indexes the scheduled jobs due at now, 1000 at most, the others on
+-- the next calls
+local function promote(now)
+	local due = redis.call("zrangebyscore", Q .. ":scheduled", "-inf", now, "WITHSCORES", "LIMIT", 0, 1000)
+	for i=1, #due, 2 do
+		redis.call("zrem", Q .. ":scheduled", due[i])
+		local group = redis.call("hget", Q .. ":groups", due[i])
+		if group then group_ready(group) else lane_add(due[i], due[i+1]) end
+	end
+end
+-- due_jobs returns up to limit due jobs with their score, by priority then
+-- due date, one per group as only the next job of a group is in a lane. The
+-- priority of a job grows by 1 every aging nanoseconds it waits when aging is
+-- positive. Jobs over a concurrency limit are blocked out of their lane. The
+-- tenants with due jobs take turns starting after the tenant served last, the
+-- jobs without tenant taking their turn as tenant "". The jobs are removed
+-- from their lane.
+local function due_jobs(now, limit, aging)
+	promote(now)
+	limit_unblock()
+	-- take returns the next due job of tenant which can run
+	local function take(tenant)
+		while true do
+			local best
+			for _, priority in ipairs(redis.call("zrevrange", Q .. ":lanes:" .. tenant, 0, -1)) do
+				local head = redis.call("zrange", Q .. ":lane:" .. priority .. ":" .. tenant, 0, 0, "WITHSCORES")
+				local rank = tonumber(priority)
+				if aging > 0 then rank = rank + math.floor((now - head[2]) / aging) end
+				if not best or rank > best[3] or (rank == best[3] and tonumber(head[2]) < tonumber(best[2])) then
+					best = {head[1], head[2], rank}
+				end
+				if aging <= 0 then break end
+			end
+			if not best then return nil end
+			local ok, counter, max = limit_allows(best[1])
+			if ok then
+				lane_remove(best[1])
+				return best[1], best[2]
+			end
+			limit_block(best[1], best[2], counter, max)
+		end
+	end
+	local res, last = {}, redis.call("get", Q .. ":tenants:last")
+	local served
+	while #res < limit * 2 do
+		local t = last and redis.call("zrangebylex", Q .. ":lanes", "(" .. last, "+", "LIMIT", 0, 1) or {}
+		if #t == 0 then t = redis.call("zrangebylex", Q .. ":lanes", "-", "+", "LIMIT", 0, 1) end
+		local tenant = t[1]
+		if not tenant then break end
+		local id, when = take(tenant)
+		if id then
+			table.insert(res, id)
+			table.insert(res, when)
+			served = tenant
+		else
+			-- a tenant without lane isn't listed anymore
+			redis.call("zrem", Q .. ":lanes", tenant)
+		end
+		last = tenant
+	end
+	if served then redis.call("set", Q .. ":tenants:last", served) end
+	return res
+end
+local function group_lock(id)
+	local group = redis.call("hget", Q .. ":groups", id)
+	if not group then return end
+	redis.call("hset", Q .. ":grouplocks", group, id)
+	group_ready(group)
+end
+-- group_release frees the group of a job leaving the queue
+local function group_release(id)
+	local group = redis.call("hget", Q .. ":groups", id)
+	if not group then return end
+	if redis.call("hget", Q .. ":grouplocks", group) == id then redis.call("hdel", Q .. ":grouplocks", group) end
+	redis.call("zrem", Q .. ":group:" .. group, id)
+	redis.call("hdel", Q .. ":groups", id)
+	group_ready(group)
+end
+`
+
+// enqueueLua stores and queues the jobs pushed by a script, it follows
+// groupLua.
+const enqueueLua = `
+-- store_job stores the job with its value and the indexes of its attributes
+local function store_job(job, value)
+	-- a job pushed again is indexed again with its attributes
+	unwait(job.id)
+	redis.call("hset", Q .. ":values", job.id, value)
+	for field, key in pairs({group = ":groups", priority = ":priorities", tenant = ":tenants"}) do
+		if job[field] then
+			redis.call("hset", Q .. key, job.id, job[field])
+		else
+			redis.call("hdel", Q .. key, job.id)
+		end
+	end
+	if job.batch then redis.call("hset", Q .. ":batches", job.id, job.batch) end
+end
+-- queue_job queues the stored job id, due at when
+local function queue_job(id, when)
+	wait(id, when)
+	status(id, "scheduled", when, false)
+end
+-- enqueue pushes the job packed in value
+local function enqueue(value)
+	local job = cmsgpack.unpack(value)
+	store_job(job, value)
+	queue_job(job.id, job.when)
+	audit("push", job.id)
+end
+-- chain pushes the follow-up of the job id completing, its then job when ok
+-- and its catch job otherwise
+local function chain(id, ok)
+	local value = redis.call("hget", Q .. ":values", id)
+	if not value then return end
+	local job = cmsgpack.unpack(value)
+	local next = ok and job["then"] or job.catch
+	if next then enqueue(cmsgpack.pack(next)) end
+end
+`
+
+// batchLua counts the completed jobs of the batches of the queue and pushes
+// the callback of a batch once all its jobs completed. It follows enqueueLua.
+const batchLua = `
+-- batch_finish pushes the callback of a batch without pending jobs
+local function batch_finish(batch)
+	local key = Q .. ":batch:" .. batch
+	local conf = redis.call("hmget", key, "pending", "callback", "retention")
+	if tonumber(conf[1] or 0) > 0 then return end
+	if conf[2] then
+		enqueue(conf[2])
+		redis.call("hdel", key, "callback")
+	end
+	local retention = tonumber(conf[3] or 0)
+	if retention > 0 then
+		redis.call("pexpire", key, retention)
+		redis.call("srem", Q .. ":batchids", batch)
+	end
+end
+-- batch_done counts the job id leaving the queue as succeeded when ok, as
+-- failed otherwise
+local function batch_done(id, ok)
+	local batch = redis.call("hget", Q .. ":batches", id)
+	if not batch then return end
+	redis.call("hdel", Q .. ":batches", id)
+	local key = Q .. ":batch:" .. batch
+	redis.call("hincrby", key, ok and "succeeded" or "failed", 1)
+	redis.call("hincrby", key, "pending", -1)
+	batch_finish(batch)
+end
+`
+
+// depsLua parks the jobs depending on other jobs of the queue until these
+// succeed, and moves them to the dead jobs when one of these fails. Its job_end,
+// job_dead and job_cancel end the jobs leaving the queue. It follows resultLua
+// and groupLua.
+const depsLua = enqueueLua + batchLua + `
+-- deps_succeeded returns whether the job id which left the queue succeeded,
+-- from its status record or its result
+local function deps_succeeded(id)
+	local rec = redis.call("hget", Q .. ":status", id)
+	if rec then return cmsgpack.unpack(rec).state == "succeeded" end
+	local res = redis.call("get", Q .. ":result:" .. id)
+	return res and cmsgpack.unpack(res).error == "" or false
+end
+-- deps_park parks the pushed job id until its parents still in the queue
+-- succeed, it returns the count of these parents, or false and the reason
+-- when one is dead, unknown or the job itself
+local function deps_park(id, parents)
+	local waiting, seen = {}, {}
+	for _, parent in ipairs(parents) do
+		if parent == id then return false, "job depends on itself" end
+		if not seen[parent] then
+			seen[parent] = true
+			if redis.call("zscore", Q .. ":dead", parent) then return false, "dependency " .. parent .. " failed" end
+			if redis.call("hexists", Q .. ":values", parent) == 1 then
+				table.insert(waiting, parent)
+			elseif not deps_succeeded(parent) then
+				return false, "dependency " .. parent .. " unknown"
+			end
+		end
+	end
+	for _, parent in ipairs(waiting) do redis.call("sadd", Q .. ":children:" .. parent, id) end
+	if #waiting > 0 then redis.call("hset", Q .. ":parked", id, #waiting) end
+	return #waiting
+end
+-- deps_forget unlinks the parked job id leaving the queue from its parents,
+-- it returns whether the job was parked
+local function deps_forget(id)
+	if redis.call("hdel", Q .. ":parked", id) == 0 then return false end
+	local job = cmsgpack.unpack(redis.call("hget", Q .. ":values", id))
+	for _, parent in ipairs(job.depends_on or {}) do redis.call("srem", Q .. ":children:" .. parent, id) end
+	return true
+end
+-- deps_done queues the jobs whose parents all succeeded with the job id
+local function deps_done(id)
+	local key = Q .. ":children:" .. id
+	for _, child in ipairs(redis.call("smembers", key)) do
+		if redis.call("hincrby", Q .. ":parked", child, -1) <= 0 then
+			redis.call("hdel", Q .. ":parked", child)
+			queue_job(child, cmsgpack.unpack(redis.call("hget", Q .. ":values", child)).when)
+		end
+	end
+	redis.call("del", key)
+end
+local job_dead
+-- deps_fail moves the jobs waiting on the failed job id to the dead jobs, and
+-- the jobs waiting on them in turn
+local function deps_fail(id, ttl)
+	local key = Q .. ":children:" .. id
+	for _, child in ipairs(redis.call("smembers", key)) do
+		redis.call("hdel", Q .. ":parked", child)
+		job_dead(child, "dependency " .. id .. " failed", ttl)
+	end
+	redis.call("del", key)
+end
+-- job_end records the job id leaving the queue in state, pushes its follow-up,
+-- counts it in its batch and queues or fails the jobs depending on it, as a
+-- success when ok
+local function job_end(id, state, ok, ttl)
+	status(id, state, nil, true)
+	group_release(id)
+	tenant_forget(id)
+	chain(id, ok)
+	batch_done(id, ok)
+	if ok then deps_done(id) else deps_fail(id, ttl) end
+end
+-- job_dead moves the job id to the dead jobs, failed with msg
+job_dead = function(id, msg, ttl)
+	redis.call("zadd", Q .. ":dead", status_time(), id)
+	redis.call("hset", Q .. ":errors", id, msg)
+	store_result(Q, ttl, id, "", msg)
+	job_end(id, "dead", false, ttl)
+end
+-- job_cancel drops the canceled job id
+local function job_cancel(id, ttl)
+	store_result(Q, ttl, id, "", "job canceled")
+	job_end(id, "canceled", false, ttl)
+	for _, key in ipairs({":values", ":priorities", ":attempts", ":errors"}) do redis.call("hdel", Q .. key, id) end
+end
+`
+
+var popJobsScript = redis.NewScript(1, trackLua+resultLua+rateLua+groupLua+depsLua+`
 local id_queue = KEYS[1]
 local content_queue = id_queue .. ":values"
-local timestamp = ARGV[1]
-local limit = ARGV[2]
-local keys = redis.call("zrangebyscore", id_queue, "-inf", timestamp, "LIMIT", 0, limit)
+local timestamp = ARGV[5]
+if redis.call("exists", id_queue .. ":paused") == 1 then return {} end
+local limit, aging = rate_allow(tonumber(ARGV[6])), tonumber(ARGV[7])
+if limit <= 0 then return {} end
+local keys = due_jobs(timestamp, limit, aging)
 if table.getn(keys) == 0 then return {} end
-local values = redis.call("hmget", content_queue, unpack(keys))
-redis.call("zrem", id_queue, unpack(keys))
-redis.call("hdel", content_queue, unpack(keys))
-return values`)
+local ids, res = {}, {}
+for i=1, #keys, 2 do
+	table.insert(ids, keys[i])
+	table.insert(res, keys[i])
+	table.insert(res, keys[i+1])
+	table.insert(res, redis.call("hget", content_queue, keys[i]))
+	unwait(keys[i])
+	audit("pop", keys[i])
+	-- popped jobs aren't followed anymore, they count as succeeded
+	job_end(keys[i], "in_flight", true, 0)
+end
+redis.call("hdel", content_queue, unpack(ids))
+redis.call("hdel", id_queue .. ":priorities", unpack(ids))
+rate_take(#ids)
+audit_trim()
+status_trim()
+return res`)
+
+// pushLua checks and pushes jobs to the queue Q bounded to max waiting or
+// parked jobs, 0 when unbounded, with the overflow policy of the queue. It
+// follows depsLua.
+const pushLua = `
+-- push_size returns the count of jobs waiting or parked, bounded by max
+local function push_size()
+	return redis.call("zcard", Q) + redis.call("hlen", Q .. ":parked")
+end
+-- push_check returns nil and the set of the ids new to the queue when the jobs
+-- packed in values can be pushed, {"full"} when rejecting jobs beyond the
+-- bound, {"tenant", tenant} for a tenant whose maximum size the jobs would
+-- exceed or {"batch", id} for a job pending in another batch otherwise
+local function push_check(values, max, overflow)
+	local added, fresh = {}, {}
+	for _, value in ipairs(values) do
+		local _, job = cmsgpack.unpack_one(value)
+		if not redis.call("zscore", Q, job.id) and redis.call("hexists", Q .. ":parked", job.id) == 0 then
+			fresh[job.id] = true
+		end
+		-- a pending job can't move to another batch, which would never finish
+		local pending = job.batch and redis.call("hget", Q .. ":batches", job.id)
+		if pending and pending ~= job.batch then return {"batch", job.id} end
+		local tenant_max = job.tenant and tonumber(redis.call("hget", Q .. ":tenants:max", job.tenant))
+		local key = tenant_max and Q .. ":tenant:" .. job.tenant
+		if tenant_max and not redis.call("zscore", key, job.id) then
+			added[job.tenant] = (added[job.tenant] or 0) + 1
+			if redis.call("zcard", key) + added[job.tenant] > tenant_max then return {"tenant", job.tenant} end
+		end
+	end
+	if max > 0 and overflow == "reject" then
+		local room = max - push_size()
+		for _ in pairs(fresh) do room = room - 1 end
+		if room < 0 then return {"full"} end
+	end
+	return nil, fresh
+end
+-- push_jobs pushes the checked jobs packed in values, counting them in batch
+-- when it isn't empty, and returns the ids of the jobs dropped
+local function push_jobs(values, fresh, max, overflow, batch)
+	local dropped, counted = {}, {}
+	local room = max - push_size()
+	local batch_key = Q .. ":batch:" .. batch
+	for _, value in ipairs(values) do
+		local _, job = cmsgpack.unpack_one(value)
+		local keep = true
+		if max > 0 and overflow == "drop_newest" and fresh[job.id] then
+			keep, room, fresh[job.id] = room > 0, room - 1, nil
+		end
+		if not keep then
+			table.insert(dropped, job.id)
+		else
+			if batch ~= "" and not counted[job.id] then
+				counted[job.id] = true
+				redis.call("hincrby", batch_key, "total", 1)
+				redis.call("hincrby", batch_key, "pending", 1)
+			end
+			store_job(job, value)
+			local parents, msg = 0
+			if job.depends_on then parents, msg = deps_park(job.id, job.depends_on) end
+			if not parents then
+				job_dead(job.id, msg, 0)
+			elseif parents > 0 then
+				status(job.id, "parked", job.when, false)
+			else
+				queue_job(job.id, job.when)
+			end
+			audit("push", job.id)
+		end
+	end
+	-- the jobs due first are dropped to make room
+	local excess = push_size() - max
+	if max > 0 and overflow == "drop_oldest" and excess > 0 then
+		for _, id in ipairs(redis.call("zrange", Q, 0, excess - 1)) do
+			unwait(id)
+			redis.call("hdel", Q .. ":values", id)
+			redis.call("hdel", Q .. ":attempts", id)
+			redis.call("hdel", Q .. ":errors", id)
+			redis.call("hdel", Q .. ":priorities", id)
+			status_forget(id)
+			group_release(id)
+			tenant_forget(id)
+			batch_done(id, false)
+			deps_fail(id, 0)
+			audit("remove", id)
+			table.insert(dropped, id)
+		end
+	end
+	return dropped
+end
+`
+
+// pushScript pushes the jobs from ARGV[11] to the queue bounded to ARGV[6]
+// waiting jobs, 0 when unbounded, with the overflow policy ARGV[7]. The jobs
+// make up the batch ARGV[8] when it isn't empty, with the callback ARGV[9] and
+// kept ARGV[10] milliseconds once finished. It returns
+// {"ok", dropped ids...}, or the result of push_check pushing none of them.
+var pushScript = redis.NewScript(2, trackLua+resultLua+groupLua+depsLua+pushLua+`
+local max, overflow, batch = tonumber(ARGV[6]), ARGV[7], ARGV[8]
+local values = {}
+for i=11, #ARGV do table.insert(values, ARGV[i]) end
+local err, fresh = push_check(values, max, overflow)
+if err then return err end
+redis.call("sadd", KEYS[2], ARGV[5])
+if batch ~= "" then
+	local batch_key = Q .. ":batch:" .. batch
+	redis.call("hset", batch_key, "total", 0, "pending", 0, "succeeded", 0, "failed", 0, "retention", ARGV[10])
+	if ARGV[9] ~= "" then redis.call("hset", batch_key, "callback", ARGV[9]) end
+	redis.call("sadd", Q .. ":batchids", batch)
+end
+local res = push_jobs(values, fresh, max, overflow, batch)
+table.insert(res, 1, "ok")
+if batch ~= "" then batch_finish(batch) end
+audit_trim()
+return res`)
 
-var pushScript = redis.NewScript(1, `
+var removeScript = redis.NewScript(1, trackLua+resultLua+groupLua+depsLua+`
 local id_queue = KEYS[1]
 local content_queue = id_queue .. ":values"
-for i=1, #ARGV do
-	local _, job = cmsgpack.unpack_one(ARGV[i])
-	redis.call("zadd", id_queue, job.when, job.id)
-	redis.call("hset", content_queue, job.id, job.content)
+local removed = 0
+for i=5, #ARGV do
+	local id = ARGV[i]
+	unwait(id)
+	redis.call("zrem", id_queue .. ":inflight", id)
+	redis.call("zrem", id_queue .. ":dead", id)
+	redis.call("hdel", id_queue .. ":attempts", id)
+	redis.call("hdel", id_queue .. ":errors", id)
+	redis.call("hdel", id_queue .. ":progress", id)
+	redis.call("srem", id_queue .. ":canceled", id)
+	status_forget(id)
+	group_release(id)
+	tenant_forget(id)
+	limit_release(id)
+	deps_forget(id)
+	batch_done(id, false)
+	deps_fail(id, 0)
+	redis.call("hdel", id_queue .. ":priorities", id)
+	if redis.call("hdel", content_queue, id) == 1 then
+		removed = removed + 1
+		audit("remove", id)
+	end
+end
+audit_trim()
+return removed`)
+
+var listQueuesScript = redis.NewScript(1, `
+local prefix = ARGV[1]
+local res = {}
+for _, name in ipairs(redis.call("smembers", KEYS[1])) do
+	table.insert(res, name)
+	table.insert(res, redis.call("zcard", prefix .. name))
+	table.insert(res, redis.call("exists", prefix .. name .. ":paused"))
+end
+return res`)
+
+// indexesDelLua deletes the indexes of the waiting jobs by tenant, lane and
+// group, of the parked jobs and of the batches of the queue id_queue.
+const indexesDelLua = `
+for _, id in ipairs(redis.call("hkeys", id_queue .. ":parked")) do
+	local job = cmsgpack.unpack(redis.call("hget", id_queue .. ":values", id))
+	for _, parent in ipairs(job.depends_on or {}) do redis.call("del", id_queue .. ":children:" .. parent) end
+end
+redis.call("del", id_queue .. ":parked")
+for _, batch in ipairs(redis.call("smembers", id_queue .. ":batchids")) do
+	redis.call("del", id_queue .. ":batch:" .. batch)
+end
+redis.call("del", id_queue .. ":batches", id_queue .. ":batchids")
+for _, tenant in ipairs(redis.call("smembers", id_queue .. ":tenants:waiting")) do
+	redis.call("del", id_queue .. ":tenant:" .. tenant)
+end
+redis.call("del", id_queue .. ":tenants", id_queue .. ":tenants:waiting", id_queue .. ":tenants:last")
+for _, tenant in ipairs(redis.call("zrange", id_queue .. ":lanes", 0, -1)) do
+	for _, priority in ipairs(redis.call("zrange", id_queue .. ":lanes:" .. tenant, 0, -1)) do
+		redis.call("del", id_queue .. ":lane:" .. priority .. ":" .. tenant)
+	end
+	redis.call("del", id_queue .. ":lanes:" .. tenant)
+end
+redis.call("del", id_queue .. ":lanes", id_queue .. ":scheduled")
+for _, group in ipairs(redis.call("hvals", id_queue .. ":groups")) do
+	redis.call("del", id_queue .. ":group:" .. group)
+end
+redis.call("del", id_queue .. ":groupheads")
+for _, counter in ipairs(redis.call("hkeys", id_queue .. ":blockers")) do
+	redis.call("del", id_queue .. ":blocked:" .. counter)
+end
+redis.call("del", id_queue .. ":blocked", id_queue .. ":blockers")
+`
+
+// purgeScript removes every job of the queue KEYS[1], recording their removal
+// in its audit log, and returns how many were removed.
+var purgeScript = redis.NewScript(1, trackLua+`
+local id_queue = KEYS[1]
+local ids = redis.call("hkeys", id_queue .. ":values")
+for _, id in ipairs(ids) do audit("remove", id) end
+audit_trim()
+local count = #ids
+`+indexesDelLua+`redis.call("del", id_queue, id_queue .. ":values", id_queue .. ":inflight",
+	id_queue .. ":dead", id_queue .. ":attempts", id_queue .. ":errors", id_queue .. ":canceled",
+	id_queue .. ":progress", id_queue .. ":status", id_queue .. ":status:done", id_queue .. ":groups",
+	id_queue .. ":grouplocks", id_queue .. ":running", id_queue .. ":running:jobs", id_queue .. ":priorities")
+return count`)
+
+// deleteScript removes every key of the queue KEYS[1] and unregisters it as
+// ARGV[5] from KEYS[2]. The audit log of an audited queue is kept, with the
+// removal of its jobs.
+var deleteScript = redis.NewScript(2, trackLua+`
+local id_queue = KEYS[1]
+for _, id in ipairs(redis.call("hkeys", id_queue .. ":values")) do audit("remove", id) end
+if audit_by == "" then redis.call("del", id_queue .. ":events") else audit_trim() end
+`+indexesDelLua+`redis.call("del", id_queue, id_queue .. ":values", id_queue .. ":inflight",
+	id_queue .. ":dead", id_queue .. ":attempts", id_queue .. ":errors", id_queue .. ":paused",
+	id_queue .. ":canceled", id_queue .. ":progress", id_queue .. ":status",
+	id_queue .. ":status:done", id_queue .. ":ratelimit", id_queue .. ":groups", id_queue .. ":grouplocks",
+	id_queue .. ":limits", id_queue .. ":running", id_queue .. ":running:jobs", id_queue .. ":priorities",
+	id_queue .. ":tenants:max")
+return redis.call("srem", KEYS[2], ARGV[5])`)
+
+// moveScript moves the waiting jobs of the queue KEYS[1] to the queue KEYS[2]
+// registered as ARGV[8] in KEYS[3], the jobs ARGV[12..] or the jobs due
+// between ARGV[9] and ARGV[10], ARGV[11] of them at most when positive. The
+// moves are recorded as removals in the audit log of KEYS[1], with the
+// tracking arguments ARGV[1..4], and as pushes in the audit log of KEYS[2],
+// with the audit arguments ARGV[5..7]. The jobs of a
+// batch and the jobs other jobs depend on stay, as their batch and the parked
+// jobs are in KEYS[1].
+var moveScript = redis.NewScript(3, trackLua+groupLua+`
+local src, dst = KEYS[1], KEYS[2]
+local audits = {[src] = {ARGV[1], tonumber(ARGV[2]), ARGV[3]}, [dst] = {ARGV[5], tonumber(ARGV[6]), ARGV[7]}}
+-- use works on the queue q with its audit arguments
+local function use(q)
+	Q = q
+	audit_by, audit_maxlen, audit_minid = unpack(audits[q])
+end
+local min, max, limit = tonumber(ARGV[9]), tonumber(ARGV[10]), tonumber(ARGV[11])
+local ids = {}
+if #ARGV > 11 then
+	for i=12, #ARGV do table.insert(ids, ARGV[i]) end
+else
+	ids = redis.call("zrangebyscore", src, min, max)
+end
+local moved = 0
+for _, id in ipairs(ids) do
+	if limit > 0 and moved >= limit then break end
+	local when = tonumber(redis.call("zscore", src, id))
+	local held = redis.call("hexists", src .. ":batches", id) == 1 or redis.call("exists", src .. ":children:" .. id) == 1
+	if when and when >= min and when <= max and not held then
+		use(src)
+		unwait(id)
+		for _, key in ipairs({":values", ":attempts", ":status", ":priorities", ":groups", ":tenants"}) do
+			local value = redis.call("hget", src .. key, id)
+			if value then redis.call("hset", dst .. key, id, value) end
+		end
+		group_release(id)
+		for _, key in ipairs({":values", ":attempts", ":status", ":priorities", ":tenants"}) do
+			redis.call("hdel", src .. key, id)
+		end
+		audit("remove", id)
+		use(dst)
+		wait(id, when)
+		audit("push", id)
+		moved = moved + 1
+	end
+end
+for _, q in ipairs({src, dst}) do
+	use(q)
+	audit_trim()
+end
+if moved > 0 then redis.call("sadd", KEYS[3], ARGV[8]) end
+return moved`)
+
+// reserveLua is the prelude of the scripts reserving jobs, reserve returns the
+// jobs of Q retried and dead after their lease expired and the reserved jobs.
+const reserveLua = trackLua + resultLua + rateLua + groupLua + depsLua + `
+local function reserve(now, limit, lease, max_attempts, ttl, aging)
+	local id_queue = Q
+	local content_queue = id_queue .. ":values"
+	local inflight = id_queue .. ":inflight"
+	-- reaper: jobs whose lease expired are due again, or dead once out of attempts,
+	-- canceled jobs are dropped
+	local retried, dead = {}, {}
+	for _, id in ipairs(redis.call("zrangebyscore", inflight, "-inf", now)) do
+		redis.call("zrem", inflight, id)
+		redis.call("hdel", id_queue .. ":progress", id)
+		limit_release(id)
+		if redis.call("srem", id_queue .. ":canceled", id) == 1 then
+			job_cancel(id, ttl)
+		elseif tonumber(redis.call("hget", id_queue .. ":attempts", id) or 0) >= max_attempts then
+			job_dead(id, "lease expired", ttl)
+			table.insert(dead, id)
+		else
+			wait(id, now)
+			status(id, "expired", now, false)
+			table.insert(retried, id)
+		end
+	end
+	if redis.call("exists", id_queue .. ":paused") == 1 then return retried, dead, {} end
+	limit = rate_allow(limit)
+	if limit <= 0 then return retried, dead, {} end
+	local ids = due_jobs(now, limit, aging)
+	local res = {}
+	for i=1, #ids, 2 do
+		local id = ids[i]
+		unwait(id)
+		redis.call("zadd", inflight, now + lease, id)
+		table.insert(res, id)
+		table.insert(res, ids[i+1])
+		table.insert(res, redis.call("hincrby", id_queue .. ":attempts", id, 1))
+		table.insert(res, redis.call("hget", content_queue, id))
+		audit("pop", id)
+		status(id, "in_flight", nil, false)
+		group_lock(id)
+		limit_take(id)
+	end
+	rate_take(#ids / 2)
+	audit_trim()
+	status_trim()
+	return retried, dead, res
+end
+`
+
+var reserveScript = redis.NewScript(1, reserveLua+`
+return {reserve(
+	tonumber(ARGV[5]), tonumber(ARGV[6]), tonumber(ARGV[7]), tonumber(ARGV[8]), tonumber(ARGV[9]), tonumber(ARGV[10])
+)}`)
+
+// multiReserveScript reserves up to ARGV[2] jobs across the queues KEYS at
+// ARGV[1]. Each queue takes 9 arguments from ARGV[3]: its tracking arguments,
+// lease, max attempts, result ttl, priority aging and quota.
+var multiReserveScript = redis.NewScript(-1, reserveLua+`
+local now, left = tonumber(ARGV[1]), tonumber(ARGV[2])
+local function use(i)
+	local a = 2 + (i - 1) * 9
+	Q = KEYS[i]
+	audit_by, audit_maxlen, audit_minid = ARGV[a+1], tonumber(ARGV[a+2]), ARGV[a+3]
+	status_retention = tonumber(ARGV[a+4])
+	limit_defs, limit_pending, limit_counters, rate_tokens = nil, {}, {}, nil
+	return tonumber(ARGV[a+5]), tonumber(ARGV[a+6]), tonumber(ARGV[a+7]), tonumber(ARGV[a+8]), tonumber(ARGV[a+9])
 end
+-- each queue is served up to its quota first, the jobs left are then taken
+-- from the queues in order
+local res, full = {}, {}
+for i=1, #KEYS do
+	local lease, max_attempts, ttl, aging, quota = use(i)
+	local retried, dead, jobs = reserve(now, math.min(quota, left), lease, max_attempts, ttl, aging)
+	res[i], full[i] = {retried, dead, jobs}, #jobs / 4 >= quota
+	left = left - #jobs / 4
+end
+for i=1, #KEYS do
+	if left <= 0 then break end
+	if full[i] then
+		local lease, max_attempts, ttl, aging = use(i)
+		local _, _, jobs = reserve(now, left, lease, max_attempts, ttl, aging)
+		for _, v in ipairs(jobs) do table.insert(res[i][3], v) end
+		left = left - #jobs / 4
+	end
+end
+return res`)
+
+// resultLua stores the results of completed jobs for ttl milliseconds.
+const resultLua = `
+local function store_result(id_queue, ttl, id, value, err)
+	if ttl > 0 then
+		redis.call("set", id_queue .. ":result:" .. id, cmsgpack.pack({value = value, error = err}), "PX", ttl)
+	end
+end
+`
+
+// ackScript acknowledges the jobs in flight from ARGV[6], with their result
+// and error. A job pushed again while in flight stays queued, its completion
+// is only recorded when its new copy completes.
+var ackScript = redis.NewScript(1, trackLua+resultLua+groupLua+depsLua+`
+local id_queue, ttl = KEYS[1], tonumber(ARGV[5])
+local acked, ids = 0, {}
+for i=6, #ARGV, 3 do
+	local id = ARGV[i]
+	if redis.call("zrem", id_queue .. ":inflight", id) == 1 then
+		limit_release(id)
+		redis.call("hdel", id_queue .. ":progress", id)
+		redis.call("srem", id_queue .. ":canceled", id)
+		store_result(id_queue, ttl, id, ARGV[i+1], ARGV[i+2])
+		if not redis.call("zscore", id_queue, id) then
+			table.insert(ids, id)
+			-- only canceled jobs are acknowledged with an error
+			local ok = ARGV[i+2] == ""
+			job_end(id, ok and "succeeded" or "canceled", ok, ttl)
+		end
+		acked = acked + 1
+	end
+end
+if #ids > 0 then
+	redis.call("hdel", id_queue .. ":values", unpack(ids))
+	redis.call("hdel", id_queue .. ":priorities", unpack(ids))
+	redis.call("hdel", id_queue .. ":attempts", unpack(ids))
+	redis.call("hdel", id_queue .. ":errors", unpack(ids))
+end
+status_trim()
+return acked`)
+
+// failScript fails the job ARGV[8] in flight, it returns 1 when the job is
+// retried, 0 when it is dead, 2 when it was canceled and -1 when it isn't in
+// flight.
+var failScript = redis.NewScript(1, trackLua+resultLua+groupLua+depsLua+`
+local id_queue = KEYS[1]
+local now, max_attempts, delay, id, msg = tonumber(ARGV[5]), tonumber(ARGV[6]), tonumber(ARGV[7]), ARGV[8], ARGV[9]
+local ttl = tonumber(ARGV[10])
+if redis.call("zrem", id_queue .. ":inflight", id) == 0 then return -1 end
+limit_release(id)
+redis.call("hdel", id_queue .. ":progress", id)
+-- a job canceled while running isn't retried
+if redis.call("srem", id_queue .. ":canceled", id) == 1 then
+	job_cancel(id, ttl)
+	return 2
+end
+local attempts = tonumber(redis.call("hget", id_queue .. ":attempts", id) or 0)
+if attempts >= max_attempts then
+	job_dead(id, msg, ttl)
+	return 0
+end
+redis.call("hset", id_queue .. ":errors", id, msg)
+local when = now + delay * 2 ^ (attempts - 1)
+wait(id, when)
+status(id, "failed", when, false)
 return 1`)
 
-var removeScript = redis.NewScript(1, `
+// releaseScript puts the job ARGV[5] in flight back in the queue, due at
+// ARGV[6], without counting its attempt.
+var releaseScript = redis.NewScript(1, trackLua+groupLua+`
+local id_queue, id, now = KEYS[1], ARGV[5], tonumber(ARGV[6])
+if redis.call("zrem", id_queue .. ":inflight", id) == 0 then return 0 end
+limit_release(id)
+redis.call("hdel", id_queue .. ":progress", id)
+redis.call("hincrby", id_queue .. ":attempts", id, -1)
+wait(id, now)
+status(id, "scheduled", now, false)
+return 1`)
+
+var cancelScript = redis.NewScript(1, trackLua+resultLua+groupLua+depsLua+`
+local id_queue, ttl = KEYS[1], tonumber(ARGV[5])
+local canceled = 0
+for i=6, #ARGV do
+	local id = ARGV[i]
+	if unwait(id) or deps_forget(id) then
+		job_cancel(id, ttl)
+		audit("remove", id)
+		canceled = canceled + 1
+	elseif redis.call("zscore", id_queue .. ":inflight", id) then
+		redis.call("sadd", id_queue .. ":canceled", id)
+		canceled = canceled + 1
+	end
+end
+audit_trim()
+status_trim()
+return canceled`)
+
+var heartbeatScript = redis.NewScript(1, `
 local id_queue = KEYS[1]
-local content_queue = id_queue .. ":values"
-redis.call("zrem", id_queue, unpack(ARGV))
-return redis.call("hdel", content_queue, unpack(ARGV))`)
+local deadline, id, progress = ARGV[1], ARGV[2], ARGV[3]
+if not redis.call("zscore", id_queue .. ":inflight", id) then return 0 end
+redis.call("zadd", id_queue .. ":inflight", deadline, id)
+if progress ~= "" then redis.call("hset", id_queue .. ":progress", id, progress) end
+return 1`)
+
+var getScript = redis.NewScript(1, `
+local id_queue, id = KEYS[1], ARGV[1]
+local value = redis.call("hget", id_queue .. ":values", id)
+if not value then return {} end
+return {
+	value,
+	redis.call("zscore", id_queue, id) or "",
+	redis.call("zscore", id_queue .. ":inflight", id) or "",
+	redis.call("hget", id_queue .. ":attempts", id) or 0,
+	redis.call("hget", id_queue .. ":progress", id) or "",
+}`)
+
+var statsScript = redis.NewScript(1, `
+local id_queue, now = KEYS[1], ARGV[1]
+local oldest = redis.call("zrangebyscore", id_queue, "-inf", now, "WITHSCORES", "LIMIT", 0, 1)
+local next = redis.call("zrangebyscore", id_queue, "(" .. now, "+inf", "WITHSCORES", "LIMIT", 0, 1)
+return {
+	redis.call("zcount", id_queue, "-inf", now),
+	redis.call("zcount", id_queue, "(" .. now, "+inf"),
+	redis.call("zcard", id_queue .. ":inflight"),
+	redis.call("zcard", id_queue .. ":dead"),
+	oldest[2] or "",
+	next[2] or "",
+}`)
+
+var tenantStatsScript = redis.NewScript(1, `
+local id_queue, now = KEYS[1], ARGV[1]
+local tenants = redis.call("smembers", id_queue .. ":tenants:waiting")
+local max = redis.call("hgetall", id_queue .. ":tenants:max")
+local sizes = {}
+for i=1, #max, 2 do
+	if redis.call("sismember", id_queue .. ":tenants:waiting", max[i]) == 0 then table.insert(tenants, max[i]) end
+	sizes[max[i]] = max[i+1]
+end
+table.sort(tenants)
+local res = {}
+for _, tenant in ipairs(tenants) do
+	local key = id_queue .. ":tenant:" .. tenant
+	table.insert(res, tenant)
+	table.insert(res, redis.call("zcount", key, "-inf", now))
+	table.insert(res, redis.call("zcount", key, "(" .. now, "+inf"))
+	table.insert(res, sizes[tenant] or 0)
+end
+return res`)
+
+var workflowScript = redis.NewScript(1, `
+local id_queue, now = KEYS[1], tonumber(ARGV[1])
+local ids, seen, res = {}, {}, {}
+for i=2, #ARGV do table.insert(ids, ARGV[i]) end
+local i = 1
+while i <= #ids do
+	local id = ids[i]
+	i = i + 1
+	if not seen[id] then
+		seen[id] = true
+		local score, state = redis.call("zscore", id_queue, id), "unknown"
+		if redis.call("hexists", id_queue .. ":parked", id) == 1 then
+			state = "parked"
+		elseif score then
+			state = tonumber(score) <= now and "due" or "scheduled"
+		elseif redis.call("zscore", id_queue .. ":inflight", id) then
+			state = "in_flight"
+		elseif redis.call("zscore", id_queue .. ":dead", id) then
+			state = "dead"
+		else
+			local rec = redis.call("hget", id_queue .. ":status", id)
+			local res = redis.call("get", id_queue .. ":result:" .. id)
+			if rec then
+				state = cmsgpack.unpack(rec).state
+			elseif res then
+				local err = cmsgpack.unpack(res).error
+				state = err == "" and "succeeded" or err == "job canceled" and "canceled" or "dead"
+			end
+		end
+		table.insert(res, id)
+		table.insert(res, state)
+		for _, child in ipairs(redis.call("smembers", id_queue .. ":children:" .. id)) do table.insert(ids, child) end
+	end
+end
+return res`)
+
+// publishScript pushes the jobs from ARGV[6] to the queues of the namespace
+// ARGV[5] subscribed to the topic KEYS[1], with the bound, overflow policy and
+// tracking arguments of their subscription. ARGV[1..4] are placeholders for
+// the preludes. It returns {"ok", {queue, overflow, dropped ids...}...}, or
+// the result of push_check followed by the queue when one of the queues
+// rejects the jobs, pushing none of them.
+var publishScript = redis.NewScript(2, trackLua+resultLua+groupLua+depsLua+pushLua+`
+local subs, values, checked = redis.call("hgetall", KEYS[1]), {}, {}
+for i=6, #ARGV do table.insert(values, ARGV[i]) end
+for i=1, #subs, 2 do
+	local sub = cmsgpack.unpack(subs[i+1])
+	Q = ARGV[5] .. subs[i]
+	local err
+	err, checked[i] = push_check(values, sub.max or 0, sub.overflow or "reject")
+	if err then
+		table.insert(err, subs[i])
+		return err
+	end
+end
+local res = {"ok"}
+for i=1, #subs, 2 do
+	local sub = cmsgpack.unpack(subs[i+1])
+	local overflow = sub.overflow or "reject"
+	Q = ARGV[5] .. subs[i]
+	audit_by, audit_maxlen, audit_minid = sub.by, sub.maxlen, "0"
+	if sub.retention > 0 then
+		local _, now_ms = status_time()
+		audit_minid = tostring(now_ms - sub.retention)
+	end
+	status_retention = sub.status
+	redis.call("sadd", KEYS[2], subs[i])
+	local pushed = {subs[i], overflow}
+	for _, id in ipairs(push_jobs(values, checked[i], sub.max or 0, overflow, "")) do table.insert(pushed, id) end
+	table.insert(res, pushed)
+	audit_trim()
+	status_trim()
+end
+return res`)
@@ -2,12 +2,16 @@ package server
 
 import (
 	"context"
+	"errors"
 	"net"
 	"time"
 
 	"github.com/missena-corp/airq"
+	"github.com/missena-corp/airq/internal/grpctrace"
 	"github.com/missena-corp/airq/job"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Server struct {
@@ -15,15 +19,18 @@ type Server struct {
 	Queue *airq.Queue
 }
 
+// New returns a server of q. The trace context of the calls is propagated to
+// the jobs they push.
 func New(q *airq.Queue) Server {
 	return Server{
-		Server: grpc.NewServer(),
+		Server: grpc.NewServer(grpc.UnaryInterceptor(grpctrace.UnaryServerInterceptor)),
 		Queue:  q,
 	}
 }
 
 func (s Server) Serve(connStr string) error {
 	job.RegisterJobsServer(s.Server, s)
+	job.RegisterAdminServer(s.Server, s)
 	l, err := net.Listen("tcp", connStr)
 	if err != nil {
 		return err
@@ -35,14 +42,12 @@ func (s Server) Push(ctx context.Context, jobList *job.JobList) (*job.IdList, er
 	var jobs []*airq.Job
 	idList := new(job.IdList)
 	for _, j := range jobList.Jobs {
-		jobs = append(jobs, &airq.Job{
-			ID:      j.GetId(),
-			Content: j.GetContent(),
-			Unique:  j.GetUnique(),
-			When:    time.Unix(0, j.GetWhen()),
-		})
+		jobs = append(jobs, fromProto(j))
+	}
+	ids, err := s.Queue.PushContext(ctx, jobs...)
+	if errors.Is(err, airq.ErrQueueFull) || errors.Is(err, airq.ErrTenantFull) {
+		return idList, status.Error(codes.ResourceExhausted, err.Error())
 	}
-	ids, err := s.Queue.Push(jobs...)
 	if err != nil || len(ids) == 0 {
 		return idList, err
 	}
@@ -52,6 +57,27 @@ func (s Server) Push(ctx context.Context, jobList *job.JobList) (*job.IdList, er
 	return idList, nil
 }
 
+// Publish pushes the jobs to the queues subscribed to the topic, see
+// airq.Topic.
+func (s Server) Publish(ctx context.Context, p *job.Publication) (*job.IdList, error) {
+	var jobs []*airq.Job
+	idList := new(job.IdList)
+	for _, j := range p.GetJobs() {
+		jobs = append(jobs, fromProto(j))
+	}
+	ids, err := s.Queue.Topic(p.GetTopic()).PublishContext(ctx, jobs...)
+	if errors.Is(err, airq.ErrQueueFull) || errors.Is(err, airq.ErrTenantFull) {
+		return idList, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if err != nil {
+		return idList, err
+	}
+	for _, id := range ids {
+		idList.Ids = append(idList.Ids, &job.Id{Id: id})
+	}
+	return idList, nil
+}
+
 func (s Server) Remove(ctx context.Context, jobs *job.IdList) (*job.Void, error) {
 	var ids []string
 	for _, i := range jobs.GetIds() {
@@ -59,3 +85,136 @@ func (s Server) Remove(ctx context.Context, jobs *job.IdList) (*job.Void, error)
 	}
 	return &job.Void{}, s.Queue.Remove(ids...)
 }
+
+func (s Server) Cancel(ctx context.Context, jobs *job.IdList) (*job.Void, error) {
+	var ids []string
+	for _, i := range jobs.GetIds() {
+		ids = append(ids, i.Id)
+	}
+	return &job.Void{}, s.Queue.Cancel(ids...)
+}
+
+func (s Server) Get(ctx context.Context, id *job.Id) (*job.JobInfo, error) {
+	info, err := s.Queue.Get(id.GetId())
+	if err == airq.ErrNotFound {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	j := info.Job
+	jobInfo := &job.JobInfo{
+		Job:      toProto(j),
+		Attempts: int32(j.Attempts),
+		Progress: int32(info.Progress),
+		Message:  info.Message,
+	}
+	if !info.Deadline.IsZero() {
+		jobInfo.Deadline = info.Deadline.UnixNano()
+	}
+	return jobInfo, nil
+}
+
+func (s Server) Await(ctx context.Context, id *job.Id) (*job.Result, error) {
+	res, err := s.Queue.Wait(ctx, id.GetId())
+	if errors.Is(err, airq.ErrNoResults) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job.Result{Value: res.Value, Error: res.Err}, nil
+}
+
+func (s Server) ListQueues(ctx context.Context, _ *job.Void) (*job.QueueList, error) {
+	queues, err := s.Queue.ListQueues()
+	if err != nil {
+		return nil, err
+	}
+	queueList := new(job.QueueList)
+	for _, q := range queues {
+		queueList.Queues = append(queueList.Queues, &job.Queue{
+			Name:   q.Name,
+			Size:   q.Size,
+			Paused: q.Paused,
+		})
+	}
+	return queueList, nil
+}
+
+func (s Server) Purge(ctx context.Context, q *job.Queue) (*job.Count, error) {
+	n, err := s.Queue.Sibling(q.GetName()).Purge()
+	return &job.Count{Count: n}, err
+}
+
+func (s Server) Delete(ctx context.Context, q *job.Queue) (*job.Void, error) {
+	return &job.Void{}, s.Queue.Sibling(q.GetName()).Delete()
+}
+
+func (s Server) Move(ctx context.Context, req *job.MoveRequest) (*job.Count, error) {
+	filter := &airq.Filter{IDs: req.GetIds(), Limit: int(req.GetLimit())}
+	if req.GetAfter() != 0 {
+		filter.After = time.Unix(0, req.GetAfter())
+	}
+	if req.GetBefore() != 0 {
+		filter.Before = time.Unix(0, req.GetBefore())
+	}
+	src, dst := s.Queue.Sibling(req.GetSource()), s.Queue.Sibling(req.GetDestination())
+	n, err := src.MoveTo(dst, filter)
+	return &job.Count{Count: n}, err
+}
+
+func (s Server) Pause(ctx context.Context, q *job.Queue) (*job.Void, error) {
+	return &job.Void{}, s.Queue.Sibling(q.GetName()).Pause()
+}
+
+func (s Server) Resume(ctx context.Context, q *job.Queue) (*job.Void, error) {
+	return &job.Void{}, s.Queue.Sibling(q.GetName()).Resume()
+}
+
+func (s Server) SetRateLimit(ctx context.Context, l *job.RateLimit) (*job.Void, error) {
+	return &job.Void{}, s.Queue.Sibling(l.GetName()).SetRateLimit(l.GetRate(), int(l.GetBurst()))
+}
+
+// fromProto returns the job of a request with its follow-ups.
+func fromProto(j *job.Job) *airq.Job {
+	if j == nil {
+		return nil
+	}
+	return &airq.Job{
+		ID:        j.GetId(),
+		Catch:     fromProto(j.GetCatch()),
+		Content:   j.GetContent(),
+		DependsOn: j.GetDependsOn(),
+		GroupKey:  j.GetGroupKey(),
+		Metadata:  j.GetMetadata(),
+		Priority:  int(j.GetPriority()),
+		Tenant:    j.GetTenant(),
+		Then:      fromProto(j.GetThen()),
+		Timeout:   time.Duration(j.GetTimeout()),
+		Type:      j.GetType(),
+		Unique:    j.GetUnique(),
+		When:      time.Unix(0, j.GetWhen()),
+	}
+}
+
+// toProto returns the message of a job with its follow-ups.
+func toProto(j *airq.Job) *job.Job {
+	if j == nil {
+		return nil
+	}
+	return &job.Job{
+		Id:        j.ID,
+		Catch:     toProto(j.Catch),
+		Content:   j.Content,
+		DependsOn: j.DependsOn,
+		GroupKey:  j.GroupKey,
+		Metadata:  j.Metadata,
+		Priority:  int32(j.Priority),
+		Tenant:    j.Tenant,
+		Then:      toProto(j.Then),
+		Timeout:   int64(j.Timeout),
+		Type:      j.Type,
+		When:      j.When.UnixNano(),
+	}
+}
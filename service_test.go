@@ -11,7 +11,13 @@ import (
 	"github.com/missena-corp/airq"
 	"github.com/missena-corp/airq/client"
 	"github.com/missena-corp/airq/server"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func newPool() *redis.Pool {
@@ -34,21 +40,16 @@ func randomName() string {
 	return base64.URLEncoding.EncodeToString(b)
 }
 
-func setup(t *testing.T) (*airq.Queue, func()) {
+func setup(t *testing.T, opts ...airq.Option) (*airq.Queue, func()) {
 	t.Parallel()
 	name := randomName()
-	q := airq.New(name, airq.WithPool(newPool()))
-	teardown := func() {
-		conn := q.Pool.Get()
-		conn.Send("DEL", q.Name)
-		conn.Send("DEL", q.Name+":values")
-		conn.Close()
-	}
+	q := airq.New(name, append([]airq.Option{airq.WithPool(newPool())}, opts...)...)
+	teardown := func() { q.Delete() }
 	return q, teardown
 }
 
 func TestService(t *testing.T) {
-	q, teardown := setup(t)
+	q, teardown := setup(t, airq.WithResultTTL(time.Minute))
 	defer teardown()
 
 	connStr := ":42039"
@@ -78,4 +79,171 @@ func TestService(t *testing.T) {
 	if err := cli.Remove(context.Background(), "foo"); err != nil {
 		t.Error(err)
 	}
+	if info, err := cli.Get(context.Background(), "baz"); err != nil || info.GetJob().GetContent() != "qux" {
+		t.Error("Expected to get the job baz, got", info, err)
+	}
+	if _, err := cli.Get(context.Background(), "foo"); status.Code(err) != codes.NotFound {
+		t.Error("Expected the removed job not to be found, got", err)
+	}
+	if err := cli.Cancel(context.Background(), "baz"); err != nil {
+		t.Error(err)
+	}
+	if res, err := cli.Await(context.Background(), "baz"); err != nil || res.Err != "job canceled" {
+		t.Error("Expected the result of the canceled job, got", res, err)
+	}
+	topic := q.Topic(randomName())
+	defer topic.Unsubscribe(q)
+	if err := topic.Subscribe(q); err != nil {
+		t.Error(err)
+	}
+	if ids, err := cli.Publish(context.Background(), topic.Name, &airq.Job{ID: "pub", Content: "news"}); err != nil || len(ids.GetIds()) != 1 {
+		t.Error("Expected the job to be published, got", ids, err)
+	}
+	if info, err := cli.Get(context.Background(), "pub"); err != nil || info.GetJob().GetContent() != "news" {
+		t.Error("Expected the published job in the subscribed queue, got", info, err)
+	}
+}
+
+func TestServiceFull(t *testing.T) {
+	q, teardown := setup(t, airq.WithMaxSize(1, airq.OverflowReject))
+	defer teardown()
+
+	connStr := ":42042"
+
+	srv := server.New(q)
+	go srv.Serve(connStr)
+	defer srv.Stop()
+	// wait for the grpc server to be up
+	time.Sleep(200 * time.Millisecond)
+
+	conn, err := grpc.Dial(connStr, grpc.WithInsecure())
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	cli := client.New(conn)
+	if _, err := cli.Push(context.Background(), &airq.Job{Content: "first"}); err != nil {
+		t.Error(err)
+	}
+	if _, err := cli.Push(context.Background(), &airq.Job{Content: "full"}); status.Code(err) != codes.ResourceExhausted {
+		t.Error("Expected the full queue to be exhausted, got", err)
+	}
+}
+
+func TestAdminService(t *testing.T) {
+	q, teardown := setup(t, airq.WithNamespace(randomName()))
+	defer teardown()
+	other := q.Sibling(randomName())
+	defer other.Delete()
+
+	connStr := ":42040"
+
+	srv := server.New(q)
+	go srv.Serve(connStr)
+	defer srv.Stop()
+	// wait for the grpc server to be up
+	time.Sleep(200 * time.Millisecond)
+
+	conn, err := grpc.Dial(connStr, grpc.WithInsecure())
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	cli := client.New(conn)
+	ctx := context.Background()
+	if _, err := cli.Push(ctx, &airq.Job{Content: "foo"}, &airq.Job{Content: "bar"}); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	queueList, err := cli.ListQueues(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(queueList.Queues) != 1 || queueList.Queues[0].Size != 2 {
+		t.Error("Expected 1 queue holding 2 jobs, got", queueList.Queues)
+	}
+
+	if err := cli.Pause(ctx, q.Name); err != nil {
+		t.Error(err)
+	}
+	if job, _ := q.Pop(); job != "" {
+		t.Error("Expected paused queue to return no job, got", job)
+	}
+	if err := cli.Resume(ctx, q.Name); err != nil {
+		t.Error(err)
+	}
+	if err := cli.SetRateLimit(ctx, q.Name, 5, 0); err != nil {
+		t.Error(err)
+	}
+	if rate, burst, _ := q.RateLimit(); rate != 5 || burst != 5 {
+		t.Error("Expected a rate limit of 5/s in bursts of 5, got", rate, burst)
+	}
+
+	if n, err := cli.Move(ctx, q.Name, other.Name, &airq.Filter{Limit: 1}); err != nil || n != 1 {
+		t.Error("Expected 1 job moved, got", n, err)
+	}
+	if n, err := cli.Purge(ctx, other.Name); err != nil || n != 1 {
+		t.Error("Expected 1 job purged, got", n, err)
+	}
+	if err := cli.Delete(ctx, q.Name); err != nil {
+		t.Error(err)
+	}
+	if queueList, _ = cli.ListQueues(ctx); len(queueList.Queues) != 1 {
+		t.Error("Expected only the purged queue to be left, got", queueList.Queues)
+	}
+}
+
+func TestServiceTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	// the interceptors use the global provider and propagator, the test isn't
+	// run in parallel so that the other tests don't see them
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+	defer otel.SetTextMapPropagator(otel.GetTextMapPropagator())
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	q := airq.New(randomName(), airq.WithPool(newPool()), airq.WithTracerProvider(provider))
+	defer q.Delete()
+
+	connStr := ":42041"
+
+	srv := server.New(q)
+	go srv.Serve(connStr)
+	defer srv.Stop()
+	// wait for the grpc server to be up
+	time.Sleep(200 * time.Millisecond)
+
+	conn, err := grpc.Dial(connStr, grpc.WithInsecure(), grpc.WithUnaryInterceptor(client.UnaryInterceptor))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	cli := client.New(conn)
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	if _, err := cli.Push(ctx, &airq.Job{Content: "traced", When: time.Now()}); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	parent.End()
+
+	jobs, _ := q.Reserve(1)
+	if len(jobs) != 1 {
+		t.Error("Expected a job, got", jobs)
+		t.FailNow()
+	}
+	_, span := q.StartSpan(context.Background(), jobs[0])
+	span.End()
+
+	names := map[string]bool{}
+	for _, s := range exporter.GetSpans() {
+		if s.SpanContext.TraceID() == parent.SpanContext().TraceID() {
+			names[s.Name] = true
+		}
+	}
+	for _, name := range []string{"/Jobs/Push", q.Name + " publish", q.Name + " process"} {
+		if !names[name] {
+			t.Error("Expected span", name, "in the trace of the request, got", names)
+		}
+	}
 }
@@ -0,0 +1,42 @@
+package airq
+
+import (
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Stats is a snapshot of the jobs of a queue.
+type Stats struct {
+	Due           int64         // jobs ready to be popped
+	Scheduled     int64         // jobs scheduled in the future
+	InFlight      int64         // jobs reserved and not acknowledged yet
+	Dead          int64         // jobs out of attempts
+	OldestDue     time.Duration // age of the oldest due job
+	NextScheduled time.Time     // due date of the next scheduled job, zero if none
+}
+
+// Stats returns the statistics of the queue, read in a single script call.
+func (q *Queue) Stats() (*Stats, error) {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	now := time.Now()
+	res, err := redis.Values(statsScript.Do(c, q.key(), now.UnixNano()))
+	if err != nil {
+		return nil, err
+	}
+	stats := new(Stats)
+	var oldest, next string
+	if _, err := redis.Scan(res, &stats.Due, &stats.Scheduled, &stats.InFlight, &stats.Dead, &oldest, &next); err != nil {
+		return nil, err
+	}
+	if oldest != "" {
+		stats.OldestDue = now.Sub(parseScore(oldest))
+	}
+	if next != "" {
+		stats.NextScheduled = parseScore(next)
+	}
+	return stats, nil
+}
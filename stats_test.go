@@ -0,0 +1,35 @@
+package airq
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	next := time.Now().Add(time.Hour)
+	addJobs(t, q, []Job{
+		Job{Content: "old", When: time.Now().Add(-time.Minute)},
+		Job{Content: "recent"},
+		Job{Content: "reserved", When: time.Now().Add(-2 * time.Minute)},
+		Job{Content: "scheduled", When: next},
+	})
+	q.Reserve(1)
+
+	stats, err := q.Stats()
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if stats.Due != 2 || stats.Scheduled != 1 || stats.InFlight != 1 || stats.Dead != 0 {
+		t.Error("Unexpected counts", stats)
+	}
+	if stats.OldestDue < time.Minute || stats.OldestDue > 2*time.Minute {
+		t.Error("Expected the oldest due job to be a minute old, was", stats.OldestDue)
+	}
+	if d := stats.NextScheduled.Sub(next); d < -time.Millisecond || d > time.Millisecond {
+		t.Error("Expected the next scheduled job at", next, "but got", stats.NextScheduled)
+	}
+}
@@ -0,0 +1,101 @@
+package airq
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/vmihailenco/msgpack"
+)
+
+// State is a step of the lifecycle of a job.
+type State string
+
+const (
+	StateParked    State = "parked" // waiting for the jobs it depends on
+	StateScheduled State = "scheduled"
+	StateDue       State = "due"
+	StateInFlight  State = "in_flight"
+	StateSucceeded State = "succeeded"
+	StateFailed    State = "failed" // failed and waiting for a retry
+	StateDead      State = "dead"
+	StateCanceled  State = "canceled"
+	StateExpired   State = "expired" // lease expired and waiting for a retry
+	StateUnknown   State = "unknown" // not in the queue, without status record nor result
+)
+
+// JobStatus is the status record of a job.
+type JobStatus struct {
+	ID    string
+	State State
+	// When is the due date of a job waiting in the queue.
+	When time.Time
+	// Times holds the time of the last transition to each state.
+	Times map[State]time.Time
+}
+
+type statusRecord struct {
+	State string            `msgpack:"state"`
+	When  string            `msgpack:"when"`
+	Times map[string]string `msgpack:"times"`
+}
+
+// WithStatus tracks the status of the jobs of the queue, see Queue.Status.
+// Records of succeeded, dead and canceled jobs are removed after retention, 0
+// keeps them until the job is removed. Popped jobs are recorded as in flight
+// and removed after retention too.
+func WithStatus(retention time.Duration) Option {
+	return func(q *Queue) { q.statusRetention = &retention }
+}
+
+// trackArgs are the first arguments of the scripts recording their operations,
+// in the audit log and in the status of the jobs.
+func (q *Queue) trackArgs() redis.Args {
+	retention := int64(-1)
+	if q.statusRetention != nil {
+		retention = q.statusRetention.Milliseconds()
+	}
+	return append(q.auditArgs(), retention)
+}
+
+// Status returns the status records of jobs of a queue tracking them, nil for
+// the jobs without record. A waiting job is due once its due date is passed.
+func (q *Queue) Status(ids ...string) ([]*JobStatus, error) {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	values, err := redis.ByteSlices(c.Do("HMGET", redis.Args{q.key() + ":status"}.AddFlat(ids)...))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	statuses := make([]*JobStatus, len(ids))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		var rec statusRecord
+		if err := msgpack.Unmarshal(value, &rec); err != nil {
+			return nil, err
+		}
+		s := &JobStatus{ID: ids[i], State: State(rec.State), Times: make(map[State]time.Time)}
+		for state, t := range rec.Times {
+			s.Times[State(state)] = parseNano(t)
+		}
+		switch s.State {
+		case StateScheduled, StateFailed, StateExpired:
+			s.When = parseNano(rec.When)
+			if !s.When.After(now) {
+				s.State, s.Times[StateDue] = StateDue, s.When
+			}
+		}
+		statuses[i] = s
+	}
+	return statuses, nil
+}
+
+func parseNano(s string) time.Time {
+	ns, _ := strconv.ParseInt(s, 10, 64)
+	return time.Unix(0, ns)
+}
@@ -0,0 +1,60 @@
+package airq
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStatus(t *testing.T) {
+	q, teardown := setup(t, WithStatus(time.Minute))
+	defer teardown()
+
+	ids, _ := q.Push(
+		&Job{Content: "due", When: time.Now().Add(-time.Second)},
+		&Job{Content: "failed", When: time.Now().Add(-time.Second)},
+		&Job{Content: "scheduled", When: time.Now().Add(time.Hour)},
+	)
+	statuses, err := q.Status(append(ids, "unknown")...)
+	if err != nil || len(statuses) != 4 || statuses[3] != nil {
+		t.Error("Expected a status by job, got", statuses, err)
+		t.FailNow()
+	}
+	if statuses[0].State != StateDue || statuses[2].State != StateScheduled {
+		t.Error("Expected due and scheduled jobs, got", statuses[0], statuses[2])
+	}
+
+	q.Reserve(2)
+	if statuses, _ = q.Status(ids[0]); statuses[0].State != StateInFlight {
+		t.Error("Expected the reserved job to be in flight, got", statuses[0])
+	}
+	q.Ack(ids[0])
+	q.Fail(ids[1], errors.New("boom"))
+	statuses, _ = q.Status(ids[:2]...)
+	done := statuses[0]
+	if done.State != StateSucceeded {
+		t.Error("Expected the acknowledged job to have succeeded, got", done)
+	}
+	for _, state := range []State{StateScheduled, StateDue, StateInFlight, StateSucceeded} {
+		if done.Times[state].IsZero() {
+			t.Error("Expected the time of the transition to", state, "got", done.Times)
+		}
+	}
+	if failed := statuses[1]; failed.State != StateFailed || !failed.When.After(time.Now()) {
+		t.Error("Expected the failed job to wait for a retry, got", failed)
+	}
+}
+
+func TestStatusRetention(t *testing.T) {
+	q, teardown := setup(t, WithStatus(time.Millisecond))
+	defer teardown()
+
+	ids, _ := q.Push(&Job{Content: "done"})
+	q.Reserve(1)
+	q.Ack(ids...)
+	time.Sleep(5 * time.Millisecond)
+	q.Reserve(1)
+	if statuses, _ := q.Status(ids...); statuses[0] != nil {
+		t.Error("Expected the record of the done job to be removed, got", statuses[0])
+	}
+}
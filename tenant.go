@@ -0,0 +1,62 @@
+package airq
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// ErrTenantFull is returned when pushed jobs would exceed the maximum size of
+// their tenant, none of the jobs is pushed then.
+var ErrTenantFull = errors.New("tenant full")
+
+// TenantStats is the depth of a tenant of a queue.
+type TenantStats struct {
+	Tenant    string
+	Due       int64 // jobs of the tenant ready to be popped
+	Scheduled int64 // jobs of the tenant scheduled in the future
+	MaxSize   int   // maximum of waiting jobs of the tenant, 0 if unbounded
+}
+
+// SetTenantMaxSize allows at most n jobs of tenant waiting in the queue, due or
+// scheduled, a size of 0 removes the maximum.
+func (q *Queue) SetTenantMaxSize(tenant string, n int) error {
+	if tenant == "" {
+		return fmt.Errorf("no tenant provided")
+	}
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	var err error
+	if n <= 0 {
+		_, err = c.Do("HDEL", q.key()+":tenants:max", tenant)
+	} else {
+		_, err = c.Do("HSET", q.key()+":tenants:max", tenant, n)
+	}
+	return err
+}
+
+// TenantStats returns the depth of the tenants with waiting jobs or a maximum
+// size, sorted by tenant.
+func (q *Queue) TenantStats() ([]TenantStats, error) {
+	c, managed := q.conn()
+	if managed {
+		defer c.Close()
+	}
+	res, err := redis.Values(tenantStatsScript.Do(c, q.key(), time.Now().UnixNano()))
+	if err != nil {
+		return nil, err
+	}
+	stats := []TenantStats{}
+	for len(res) > 0 {
+		var s TenantStats
+		if res, err = redis.Scan(res, &s.Tenant, &s.Due, &s.Scheduled, &s.MaxSize); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+	return stats, nil
+}
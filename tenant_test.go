@@ -0,0 +1,87 @@
+package airq
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTenantFairness(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	past := time.Now().Add(-time.Hour)
+	for i := 0; i < 10; i++ {
+		addJobs(t, q, []Job{Job{Content: "big", Tenant: "big", Unique: true, When: past}})
+	}
+	addJobs(t, q, []Job{
+		Job{Content: "small", Tenant: "small", Unique: true, When: time.Now().Add(-time.Second)},
+		Job{Content: "small", Tenant: "small", Unique: true, When: time.Now().Add(-time.Second)},
+		Job{Content: "none", When: time.Now().Add(-time.Second)},
+	})
+	jobs, err := q.Reserve(6)
+	if err != nil {
+		t.Error(err)
+	}
+	counts := map[string]int{}
+	for _, j := range jobs {
+		counts[j.Content]++
+	}
+	if counts["big"] != 3 || counts["small"] != 2 || counts["none"] != 1 {
+		t.Error("Expected the tenants to take turns, got", counts)
+	}
+	if stats, _ := q.TenantStats(); len(stats) != 1 || stats[0].Tenant != "big" || stats[0].Due != 7 {
+		t.Error("Expected 7 jobs of big waiting, got", stats)
+	}
+	for _, j := range jobs {
+		if j.Content == "small" {
+			if err := q.Fail(j.ID, errors.New("retry")); err != nil {
+				t.Error(err)
+			}
+			break
+		}
+	}
+	if stats, _ := q.TenantStats(); len(stats) != 2 || stats[1].Tenant != "small" || stats[1].Scheduled != 1 {
+		t.Error("Expected the failed job of small to wait for its retry, got", stats)
+	}
+}
+
+func TestTenantTurns(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	addJobs(t, q, []Job{
+		Job{Content: "a1", Tenant: "a", When: time.Now().Add(-4 * time.Second)},
+		Job{Content: "a2", Tenant: "a", When: time.Now().Add(-3 * time.Second)},
+		Job{Content: "b1", Tenant: "b", When: time.Now().Add(-2 * time.Second)},
+		Job{Content: "b2", Tenant: "b", When: time.Now().Add(-time.Second)},
+	})
+	for _, expected := range []string{"a1", "b1", "a2", "b2", ""} {
+		if job, _ := q.Pop(); job != expected {
+			t.Error("Expected", expected, "but got", job)
+		}
+	}
+}
+
+func TestTenantMaxSize(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	if err := q.SetTenantMaxSize("a", 2); err != nil {
+		t.Error(err)
+	}
+	addJobs(t, q, []Job{Job{Content: "1", Tenant: "a"}, Job{Content: "2", Tenant: "a"}})
+	if _, err := q.Push(&Job{Content: "1", Tenant: "a"}); err != nil {
+		t.Error("Expected a job pushed again to be accepted, got", err)
+	}
+	if _, err := q.Push(&Job{Content: "3", Tenant: "a"}, &Job{Content: "4"}); !errors.Is(err, ErrTenantFull) {
+		t.Error("Expected ErrTenantFull, got", err)
+	}
+	if n, _ := q.Pending(); n != 2 {
+		t.Error("Expected no job of the rejected push, got", n)
+	}
+	stats, err := q.TenantStats()
+	if err != nil || len(stats) != 1 || stats[0].Due != 2 || stats[0].MaxSize != 2 {
+		t.Error("Expected tenant a holding 2 jobs out of 2, got", stats, err)
+	}
+}
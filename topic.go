@@ -0,0 +1,166 @@
+package airq
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/vmihailenco/msgpack"
+)
+
+// Topic fans jobs out to the queues subscribed to it, see Publish.
+type Topic struct {
+	Name string
+
+	queue *Queue // connection and namespace of the topic
+}
+
+// subscription is how a queue subscribed to a topic tracks the jobs published
+// to it.
+type subscription struct {
+	Max       int    `msgpack:"max"`      // MaxSize of the queue
+	Overflow  string `msgpack:"overflow"` // overflow policy of the queue
+	By        string `msgpack:"by"`
+	MaxLen    int64  `msgpack:"maxlen"`
+	Retention int64  `msgpack:"retention"` // audit retention in milliseconds
+	Status    int64  `msgpack:"status"`    // status retention in milliseconds, -1 if untracked
+}
+
+// NewTopic defines a new Topic.
+func NewTopic(name string, opts ...Option) *Topic {
+	return New("", opts...).Topic(name)
+}
+
+// Topic returns the topic called name sharing the connection and namespace of
+// q.
+func (q *Queue) Topic(name string) *Topic {
+	return &Topic{Name: name, queue: q.Sibling(name)}
+}
+
+func (t *Topic) key() string { return t.queue.prefix() + "airq:topic:" + t.Name }
+
+// Subscribe subscribes q to the topic, the jobs published from then on are
+// pushed to q with its MaxSize, Overflow, audit and status options at the time
+// of subscription.
+func (t *Topic) Subscribe(q *Queue) error {
+	if q.Namespace != t.queue.Namespace {
+		return fmt.Errorf("queue %s isn't in the namespace of topic %s", q.Name, t.Name)
+	}
+	sub := subscription{Max: q.MaxSize, Overflow: q.Overflow.RedisArg().(string), Status: -1}
+	if q.audit != nil {
+		sub.By, sub.MaxLen, sub.Retention = q.audit.By, q.audit.MaxLen, q.audit.Retention.Milliseconds()
+	}
+	if q.statusRetention != nil {
+		sub.Status = q.statusRetention.Milliseconds()
+	}
+	value, err := msgpack.Marshal(&sub)
+	if err != nil {
+		return err
+	}
+	c, managed := t.queue.conn()
+	if managed {
+		defer c.Close()
+	}
+	_, err = c.Do("HSET", t.key(), q.Name, value)
+	return err
+}
+
+// Unsubscribe stops pushing the jobs published to the topic to q.
+func (t *Topic) Unsubscribe(q *Queue) error {
+	c, managed := t.queue.conn()
+	if managed {
+		defer c.Close()
+	}
+	_, err := c.Do("HDEL", t.key(), q.Name)
+	return err
+}
+
+// Subscribers returns the sorted names of the queues subscribed to the topic.
+func (t *Topic) Subscribers() ([]string, error) {
+	c, managed := t.queue.conn()
+	if managed {
+		defer c.Close()
+	}
+	names, err := redis.Strings(c.Do("HKEYS", t.key()))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Publish pushes a copy of the jobs to every queue subscribed to the topic, in
+// a single transaction, and returns their IDs. Jobs published without
+// subscribers are dropped. The MaxSize and Overflow of each queue apply as in
+// Push, and no job is published when a queue rejects them with ErrQueueFull or
+// ErrTenantFull. The observers of the queue of the topic are notified of the
+// pushes to every queue. Published jobs can't have dependencies.
+func (t *Topic) Publish(jobs ...*Job) ([]string, error) {
+	return t.PublishContext(context.Background(), jobs...)
+}
+
+// PublishContext is Publish recorded in a producer span of the trace of ctx,
+// see PushContext.
+func (t *Topic) PublishContext(ctx context.Context, jobs ...*Job) ([]string, error) {
+	return t.queue.produce(ctx, jobs, func() ([]string, error) { return t.publish(jobs) })
+}
+
+func (t *Topic) publish(jobs []*Job) ([]string, error) {
+	if len(jobs) == 0 {
+		return []string{}, fmt.Errorf("no jobs provided")
+	}
+	args := redis.Args{t.key(), t.queue.registry(), "", 0, "0", -1, t.queue.prefix()}
+	ids := make([]string, 0, len(jobs))
+	for _, j := range jobs {
+		args = args.AddFlat(j.String())
+		if len(j.DependsOn) > 0 {
+			return nil, fmt.Errorf("job %s published with dependencies", j.ID)
+		}
+		if err := j.checkFollowUps(); err != nil {
+			return nil, err
+		}
+		ids = append(ids, j.ID)
+	}
+	c, managed := t.queue.conn()
+	if managed {
+		defer c.Close()
+	}
+	res, err := redis.Values(publishScript.Do(c, args...))
+	if err != nil {
+		return nil, err
+	}
+	if status, _ := redis.String(res[0], nil); status != "ok" {
+		return nil, publishError(status, res[1:])
+	}
+	for _, v := range res[1:] {
+		pushed, err := redis.Strings(v, nil)
+		if err != nil {
+			return nil, err
+		}
+		q, dropped := t.queue.Sibling(pushed[0]), pushed[2:]
+		kept := jobs
+		if pushed[1] == OverflowDropNewest.RedisArg() && len(dropped) > 0 {
+			kept = keepJobs(jobs, dropped)
+		} else if len(dropped) > 0 {
+			q.notify(EventRemove, idJobs(dropped...), 0, nil)
+		}
+		q.notify(EventPush, kept, 0, nil)
+	}
+	return ids, nil
+}
+
+// publishError is the error of a queue rejecting published jobs, from the
+// result of the publish script.
+func publishError(status string, res []interface{}) error {
+	args, _ := redis.Strings(res, nil)
+	switch {
+	case status == "full" && len(args) == 1:
+		return fmt.Errorf("%w: %s", ErrQueueFull, args[0])
+	case status == "tenant" && len(args) == 2:
+		return fmt.Errorf("%w: %s of queue %s", ErrTenantFull, args[0], args[1])
+	case status == "batch" && len(args) == 2:
+		return fmt.Errorf("%w: %s of queue %s", ErrInBatch, args[0], args[1])
+	}
+	return fmt.Errorf("unexpected publish result %s %v", status, args)
+}
@@ -0,0 +1,84 @@
+package airq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTopic(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+	other := q.Sibling(randomName())
+	defer other.Delete()
+	topic := q.Topic(randomName())
+	defer topic.Unsubscribe(q)
+
+	if ids, err := topic.Publish(&Job{Content: "dropped"}); err != nil || len(ids) != 1 {
+		t.Error("Expected a job published without subscribers to be dropped, got", ids, err)
+	}
+	for _, s := range []*Queue{q, other} {
+		if err := topic.Subscribe(s); err != nil {
+			t.Error(err)
+		}
+	}
+	if subs, _ := topic.Subscribers(); len(subs) != 2 {
+		t.Error("Expected 2 subscribers, got", subs)
+	}
+	if _, err := topic.Publish(&Job{Content: "1", When: time.Now().Add(-time.Second)}); err != nil {
+		t.Error(err)
+	}
+	for _, s := range []*Queue{q, other} {
+		if job, _ := s.Pop(); job != "1" {
+			t.Error("Expected the published job in", s.Name, "got", job)
+		}
+	}
+	if err := topic.Unsubscribe(other); err != nil {
+		t.Error(err)
+	}
+	topic.Publish(&Job{Content: "2"})
+	if n, _ := other.Pending(); n != 0 {
+		t.Error("Expected no job published to the unsubscribed queue, got", n)
+	}
+	if n, _ := q.Pending(); n != 1 {
+		t.Error("Expected the job published to the subscribed queue, got", n)
+	}
+	if err := NewTopic("t", WithConn(q.Conn), WithNamespace("other")).Subscribe(q); err == nil {
+		t.Error("Expected a queue of another namespace to be refused")
+	}
+}
+
+func TestTopicBounded(t *testing.T) {
+	pushed := map[string][]string{}
+	q, teardown := setup(t, OnPush(func(e *Event) { pushed[e.Queue] = append(pushed[e.Queue], e.IDs()...) }))
+	defer teardown()
+	full, newest := q.Sibling(randomName()), q.Sibling(randomName())
+	full.MaxSize, newest.MaxSize, newest.Overflow = 1, 1, OverflowDropNewest
+	defer full.Delete()
+	defer newest.Delete()
+	topic := q.Topic(randomName())
+	for _, s := range []*Queue{full, newest} {
+		topic.Subscribe(s)
+		defer topic.Unsubscribe(s)
+	}
+
+	if _, err := topic.Publish(&Job{ID: "a", Content: "a"}, &Job{ID: "b", Content: "b"}); !errors.Is(err, ErrQueueFull) {
+		t.Error("Expected ErrQueueFull, got", err)
+	}
+	if n, _ := newest.Pending(); n != 0 {
+		t.Error("Expected no job published when a queue is full, got", n)
+	}
+	full.MaxSize = 2
+	topic.Subscribe(full)
+	if _, err := topic.Publish(&Job{ID: "a", Content: "a"}, &Job{ID: "b", Content: "b"}); err != nil {
+		t.Error(err)
+	}
+	if n, _ := newest.Pending(); n != 1 {
+		t.Error("Expected the newest job dropped, got", n)
+	}
+	expected := map[string][]string{full.Name: {"a", "b"}, newest.Name: {"a"}}
+	if !reflect.DeepEqual(pushed, expected) {
+		t.Error("Expected the pushes to be observed, got", pushed)
+	}
+}
@@ -0,0 +1,85 @@
+package airq
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/missena-corp/airq"
+
+// WithTracerProvider sets the provider of the spans of the queue, the global
+// provider is used by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(q *Queue) { q.tracerProvider = tp }
+}
+
+func (q *Queue) tracer() trace.Tracer {
+	if q.tracerProvider == nil {
+		return otel.Tracer(tracerName)
+	}
+	return q.tracerProvider.Tracer(tracerName)
+}
+
+// PushContext is Push recorded in a producer span of the trace of ctx. The span
+// context is injected in the metadata of the jobs for their consumers, see
+// StartSpan.
+func (q *Queue) PushContext(ctx context.Context, jobs ...*Job) ([]string, error) {
+	return q.pushContext(ctx, jobs, nil)
+}
+
+func (q *Queue) pushContext(ctx context.Context, jobs []*Job, b *batch) ([]string, error) {
+	return q.produce(ctx, jobs, func() ([]string, error) { return q.push(jobs, b) })
+}
+
+// produce calls push in a producer span of the trace of ctx, injected in the
+// metadata of the jobs.
+func (q *Queue) produce(ctx context.Context, jobs []*Job, push func() ([]string, error)) ([]string, error) {
+	ctx, span := q.tracer().Start(ctx, q.Name+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "airq"),
+			attribute.String("messaging.destination.name", q.Name),
+			attribute.Int("messaging.batch.message_count", len(jobs)),
+		),
+	)
+	defer span.End()
+	for _, j := range jobs {
+		if j.Metadata == nil {
+			j.Metadata = make(map[string]string)
+		}
+		otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(j.Metadata))
+	}
+	ids, err := push()
+	if err != nil {
+		span.RecordError(err)
+	}
+	return ids, err
+}
+
+// StartSpan starts the consumer span of the processing of j. The span belongs
+// to the trace which pushed j and is linked to its producer span.
+func (q *Queue) StartSpan(ctx context.Context, j *Job) (context.Context, trace.Span) {
+	producer := trace.SpanContextFromContext(
+		otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(j.Metadata)),
+	)
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "airq"),
+			attribute.String("messaging.destination.name", q.Name),
+			attribute.String("messaging.message.id", j.ID),
+			attribute.Int("airq.attempts", j.Attempts),
+			attribute.Float64("airq.wait_seconds", time.Since(j.When).Seconds()),
+		),
+	}
+	if producer.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, producer)
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: producer}))
+	}
+	return q.tracer().Start(ctx, q.Name+" process", opts...)
+}
@@ -0,0 +1,62 @@
+package airq
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracePropagation(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	// the propagator is global, the test isn't run in parallel so that the
+	// other tests don't see it
+	defer otel.SetTextMapPropagator(otel.GetTextMapPropagator())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	c, err := redis.Dial("tcp", "127.0.0.1:6379")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	defer c.Close()
+	q := New(randomName(), WithConn(c), WithTracerProvider(provider))
+	defer q.Delete()
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	if _, err := q.PushContext(ctx, &Job{Content: "traced"}); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	parent.End()
+
+	jobs, _ := q.Reserve(1)
+	if len(jobs) != 1 || jobs[0].Metadata["traceparent"] == "" {
+		t.Error("Expected the job to carry its trace context, got", jobs)
+		t.FailNow()
+	}
+	_, span := q.StartSpan(context.Background(), jobs[0])
+	span.End()
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, s := range exporter.GetSpans() {
+		if s.SpanContext.TraceID() == parent.SpanContext().TraceID() {
+			spans[s.Name] = s
+		}
+	}
+	producer, consumer := spans[q.Name+" publish"], spans[q.Name+" process"]
+	if !producer.SpanContext.IsValid() || !consumer.SpanContext.IsValid() {
+		t.Error("Expected producer and consumer spans in the trace of the request, got", spans)
+		t.FailNow()
+	}
+	if consumer.Parent.SpanID() != producer.SpanContext.SpanID() {
+		t.Error("Expected the consumer span to be a child of the producer span")
+	}
+	if len(consumer.Links) != 1 || consumer.Links[0].SpanContext.SpanID() != producer.SpanContext.SpanID() {
+		t.Error("Expected the consumer span to be linked to the producer span, got", consumer.Links)
+	}
+}
@@ -0,0 +1,160 @@
+package airq
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Handler processes a reserved job. The job is acknowledged when Process
+// returns nil, failed when it returns an error and buried when the error is
+// permanent. The context of Process ends with the Timeout of the job, which
+// is then failed and retried, or when the job is canceled, which is then
+// acknowledged.
+type Handler interface {
+	Process(ctx context.Context, j *Job) error
+}
+
+// HandlerFunc adapts a function to a Handler.
+type HandlerFunc func(ctx context.Context, j *Job) error
+
+// Process calls f(ctx, j).
+func (f HandlerFunc) Process(ctx context.Context, j *Job) error { return f(ctx, j) }
+
+type permanentError struct{ error }
+
+func (e permanentError) Unwrap() error { return e.error }
+
+// Permanent marks err as permanent, a job failing with it is buried instead of
+// retried.
+func Permanent(err error) error { return permanentError{err} }
+
+// IsPermanent tells whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
+
+// Work reserves jobs of the queue and processes them with h until ctx is
+// done. It only reserves as many jobs as it runs at once, see
+// LoopOptions.Concurrency, and keeps their lease while they run when the queue
+// has a Pool. Jobs interrupted by the end of ctx are put back in the queue
+// without counting their attempt. The Size of the options is not used.
+func (q *Queue) Work(ctx context.Context, h Handler, opts *LoopOptions) error {
+	return work(ctx, q.Reserve, h, opts, q.Pool != nil)
+}
+
+// work processes with h the jobs returned by reserve until ctx is done, at
+// once when pooled.
+func work(ctx context.Context, reserve func(int) ([]*Job, error), h Handler, opts *LoopOptions, pooled bool) error {
+	if opts == nil {
+		opts = new(LoopOptions)
+	}
+	if opts.Sleep == 0 {
+		opts.Sleep = 3 * time.Second
+	}
+	if opts.CancelCheck == 0 {
+		opts.CancelCheck = time.Second
+	}
+	n := opts.Concurrency
+	if n <= 0 || !pooled {
+		n = 1
+	}
+	slots := make(chan struct{}, n)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for ctx.Err() == nil {
+		select {
+		case <-ctx.Done():
+			continue
+		case slots <- struct{}{}:
+		}
+		free := 1
+		for full := false; free < n && !full; {
+			select {
+			case slots <- struct{}{}:
+				free++
+			default:
+				full = true
+			}
+		}
+		jobs, err := reserve(free)
+		if err != nil {
+			opts.report(err)
+			jobs = nil
+		}
+		for _, j := range jobs {
+			wg.Add(1)
+			go func(j *Job) {
+				defer wg.Done()
+				j.queue.process(ctx, h, j, opts)
+				<-slots
+			}(j)
+		}
+		for i := len(jobs); i < free; i++ {
+			<-slots
+		}
+		if len(jobs) > 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(opts.Sleep):
+		}
+	}
+	return ctx.Err()
+}
+
+// report calls OnError with err.
+func (opts *LoopOptions) report(err error) {
+	if err != nil && opts.OnError != nil {
+		opts.OnError(err)
+	}
+}
+
+func (q *Queue) process(ctx context.Context, h Handler, j *Job, opts *LoopOptions) {
+	jobCtx, cancel := q.jobContext(ctx, j, opts.CancelCheck)
+	defer cancel()
+	stop := func() {}
+	if q.Pool != nil {
+		stop = j.keepAlive(q.lease() / 2)
+	}
+	start := time.Now()
+	err := h.Process(jobCtx, j)
+	stop()
+	q.notify(EventProcess, []*Job{j}, time.Since(start), err)
+	switch {
+	case context.Cause(jobCtx) == ErrCanceled:
+		err = q.ack([]string{j.ID}, []Result{{Err: ErrCanceled.Error()}})
+	case err == nil:
+		err = q.ack([]string{j.ID}, []Result{{Value: j.result}})
+	case ctx.Err() != nil:
+		// the worker stops, the job will run again
+		err = q.release(j.ID)
+	case IsPermanent(err):
+		err = q.Bury(j.ID, err)
+	default:
+		err = q.Fail(j.ID, err)
+	}
+	opts.report(err)
+}
+
+// keepAlive sends heartbeats of the reserved job every interval until the
+// returned function is called.
+func (j *Job) keepAlive(interval time.Duration) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				j.Heartbeat()
+			}
+		}
+	}()
+	return func() { close(done) }
+}
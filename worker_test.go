@@ -0,0 +1,89 @@
+package airq
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+func TestWork(t *testing.T) {
+	q, teardown := setup(t)
+	defer teardown()
+
+	addJobs(t, q, []Job{
+		Job{Content: "ok"},
+		Job{Content: "retry"},
+		Job{Content: "bury"},
+	})
+
+	processed := []string{}
+	h := HandlerFunc(func(ctx context.Context, j *Job) error {
+		processed = append(processed, j.Content)
+		switch j.Content {
+		case "retry":
+			return errors.New("temporary")
+		case "bury":
+			return Permanent(errors.New("fatal"))
+		}
+		return nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := q.Work(ctx, h, &LoopOptions{Sleep: 10 * time.Millisecond}); err != context.DeadlineExceeded {
+		t.Error("Expected Work to stop with its context, got", err)
+	}
+
+	if len(processed) != 3 {
+		t.Error("Expected 3 jobs processed, got", processed)
+	}
+	stats, _ := q.Stats()
+	if stats.Due != 0 || stats.InFlight != 0 || stats.Scheduled != 1 || stats.Dead != 1 {
+		t.Error("Expected a job to be retried and another to be dead, got", stats)
+	}
+}
+
+func TestWorkLease(t *testing.T) {
+	pool := &redis.Pool{Dial: func() (redis.Conn, error) { return redis.Dial("tcp", "127.0.0.1:6379") }}
+	defer pool.Close()
+	q, teardown := setup(t, WithPool(pool), WithLease(40*time.Millisecond))
+	defer teardown()
+
+	addJobs(t, q, []Job{Job{Content: "slow", ID: "01"}, Job{Content: "stopped", ID: "02"}})
+	var mu sync.Mutex
+	runs := map[string]int{}
+	h := HandlerFunc(func(ctx context.Context, j *Job) error {
+		mu.Lock()
+		runs[j.ID]++
+		mu.Unlock()
+		if j.ID == "02" {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		// outlives the lease, kept by heartbeats
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	})
+	var errs []error
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	q.Work(ctx, h, &LoopOptions{Sleep: 10 * time.Millisecond, Concurrency: 2, OnError: func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}})
+
+	if runs["01"] != 1 || runs["02"] != 1 {
+		t.Error("Expected each job to run once at the same time, got", runs)
+	}
+	if len(errs) != 0 {
+		t.Error("Expected no error, got", errs)
+	}
+	info, err := q.Get("02")
+	if err != nil || info.Job.Attempts != 0 || !info.Deadline.IsZero() {
+		t.Error("Expected the interrupted job back in the queue without attempt, got", info, err)
+	}
+}